@@ -0,0 +1,215 @@
+package lengths
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// Sum returns the sum of the lengths and whether it could be computed
+// without overflowing.
+func Sum(lengths ...Length) (Length, bool) {
+	var sum, carry uint64
+	for _, l := range lengths {
+		sum, carry = bits.Add64(sum, uint64(l), 0)
+		if carry != 0 {
+			return 0, false
+		}
+	}
+	return Length(sum), true
+}
+
+// TotalWithWaste returns the sum of the lengths increased by wastePercent
+// percent, such as the fabric needed for a set of cut lengths with a 15%
+// waste factor, rounded to the closest nanometer. The boolean is false if
+// wastePercent is negative or if the total overflows.
+func TotalWithWaste(wastePercent float64, lengths ...Length) (Length, bool) {
+	if !(wastePercent >= 0) {
+		return 0, false
+	}
+	sum, ok := Sum(lengths...)
+	if !ok {
+		return 0, false
+	}
+	waste, ok := roundFloat(float64(sum) * wastePercent / 100)
+	if !ok {
+		return 0, false
+	}
+	return Sum(sum, waste)
+}
+
+// GCD returns the greatest common divisor of the lengths, the longest length
+// of which all the lengths are whole multiples. Zero lengths are ignored; it
+// returns zero if there are no other lengths.
+func GCD(lengths ...Length) Length {
+	var gcd Length
+	for _, l := range lengths {
+		a, b := gcd, l
+		for b != 0 {
+			a, b = b, a%b
+		}
+		gcd = a
+	}
+	return gcd
+}
+
+// LCM returns the least common multiple of the lengths, the shortest length
+// that is a whole multiple of all the lengths, such as the period at which two
+// repeating patterns align. It returns zero if any length is zero or if there
+// are no lengths. The boolean is false if the multiple overflows, which
+// happens quickly as multiples grow fast.
+func LCM(lengths ...Length) (Length, bool) {
+	if len(lengths) == 0 {
+		return 0, true
+	}
+	lcm := Length(1)
+	for _, l := range lengths {
+		if l == 0 {
+			return 0, true
+		}
+		hi, lo := bits.Mul64(uint64(lcm/GCD(lcm, l)), uint64(l))
+		if hi != 0 {
+			return 0, false
+		}
+		lcm = Length(lo)
+	}
+	return lcm, true
+}
+
+// QuantizeSensor returns the length snapped to the closest reading of a
+// sensor reporting lengths in fixed increments from an offset, that is the
+// closest length offset+k*increment for an integer k. Halfway values round
+// up. The length is returned unchanged if increment is zero.
+func (l Length) QuantizeSensor(increment Length, offset Length) Length {
+	if increment == 0 {
+		return l
+	}
+	first := offset % increment
+	if l <= first {
+		return first
+	}
+	q := first + (l-first)/increment*increment
+	if r := l - q; r >= increment-r && q <= MaxLength-increment {
+		q += increment
+	}
+	return q
+}
+
+// Ratio returns the dimensionless ratio a/b, such as a shoulder to waist
+// ratio. It returns zero if b is zero.
+func Ratio(a, b Length) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// PercentDiff returns the signed difference between the length and reference
+// as a percentage of reference, such as 5 for a length 5% longer than
+// reference and -5 for a length 5% shorter. It returns zero if reference is
+// zero.
+func (l Length) PercentDiff(reference Length) float64 {
+	switch {
+	case reference == 0:
+		return 0
+	case l >= reference:
+		return float64(l-reference) / float64(reference) * 100
+	default:
+		return -float64(reference-l) / float64(reference) * 100
+	}
+}
+
+// PercentOf returns the length as a percentage of whole, such as 50 for half
+// of whole. It returns zero if whole is zero.
+func (l Length) PercentOf(whole Length) float64 {
+	return Ratio(l, whole) * 100
+}
+
+// WithinPercent reports whether the length and other differ by at most
+// percent percent of the longer of the two, such as for relative tolerance
+// checks. Two zero lengths are within any non-negative percentage.
+func (l Length) WithinPercent(other Length, percent float64) bool {
+	d, longest := l-other, l
+	if l < other {
+		d, longest = other-l, other
+	}
+	return float64(d) <= percent/100*float64(longest)
+}
+
+// WeightedMean returns the mean of the lengths weighted by the weights at the
+// same index, such as per-measurement confidences, rounded to the closest
+// nanometer. Weights are relative and need not sum to 1. It returns an error
+// if the slices differ in length, if a weight is negative, infinite or NaN,
+// or if all weights are zero. The mean is computed in floating point, and
+// weights so large that the weighted sum overflows also return an error.
+func WeightedMean(lengths []Length, weights []float64) (Length, error) {
+	if len(lengths) != len(weights) {
+		return 0, errors.New("lengths: weighted mean: lengths and weights differ in count")
+	}
+	var sum, total float64
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 0) {
+			return 0, errors.New("lengths: weighted mean: invalid weight")
+		}
+		sum += float64(lengths[i]) * w
+		total += w
+	}
+	if math.IsInf(sum, 0) || math.IsInf(total, 0) {
+		return 0, errors.New("lengths: weighted mean: weights too large")
+	}
+	if total == 0 {
+		return 0, errors.New("lengths: weighted mean: zero total weight")
+	}
+	return saturate(sum / total), nil
+}
+
+// Fits returns how many whole pieces fit in the length, such as the number of
+// pieces cut from a board, and the leftover length formatted by String. A
+// zero piece fits no times and leaves the whole length as waste.
+func (l Length) Fits(piece Length) (count uint64, waste string) {
+	if piece == 0 {
+		return 0, l.String()
+	}
+	return uint64(l / piece), (l % piece).String()
+}
+
+// LayoutSpan returns the span taken by count items of itemSize laid out with
+// gap between consecutive items, count*itemSize + (count-1)*gap, and whether
+// it could be computed without overflowing. It returns zero if count is not
+// positive.
+func LayoutSpan(itemSize, gap Length, count int) (Length, bool) {
+	if count <= 0 {
+		return 0, true
+	}
+	hi, items := bits.Mul64(uint64(itemSize), uint64(count))
+	if hi != 0 {
+		return 0, false
+	}
+	hi, gaps := bits.Mul64(uint64(gap), uint64(count-1))
+	if hi != 0 {
+		return 0, false
+	}
+	return Sum(Length(items), Length(gaps))
+}
+
+// ItemsInSpan returns the largest number of items of itemSize that can be laid
+// out with gap between consecutive items within available, the inverse of
+// LayoutSpan. It returns zero if not even one item fits. Counts beyond the
+// int range, such as when both itemSize and gap are zero, are limited to it.
+func ItemsInSpan(available, itemSize, gap Length) int {
+	if available < itemSize {
+		return 0
+	}
+	step, carry := bits.Add64(uint64(itemSize), uint64(gap), 0)
+	if carry != 0 {
+		return 1
+	}
+	if step == 0 {
+		return math.MaxInt
+	}
+	n := uint64(available-itemSize)/step + 1
+	if n == 0 || n > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(n)
+}
@@ -0,0 +1,599 @@
+package lengths
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	testCases := []struct {
+		lengths []Length
+		want    Length
+		wantOK  bool
+	}{
+		{
+			lengths: nil,
+			want:    0,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{178 * Centimeter},
+			want:    178 * Centimeter,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{1 * Meter, 50 * Centimeter, 5 * Millimeter},
+			want:    1505 * Millimeter,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{MaxLength, 0},
+			want:    MaxLength,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{MaxLength, 1 * Nanometer},
+			want:    0,
+			wantOK:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotOK := Sum(tc.lengths...)
+		if got != tc.want || gotOK != tc.wantOK {
+			t.Errorf("Sum(): got %q, %t, want %q, %t", got, gotOK, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestTotalWithWaste(t *testing.T) {
+	cuts := []Length{120 * Centimeter, 80 * Centimeter, 2 * Meter}
+	testCases := []struct {
+		wastePercent float64
+		lengths      []Length
+		want         Length
+		wantOK       bool
+	}{
+		{
+			wastePercent: 0,
+			lengths:      cuts,
+			want:         4 * Meter,
+			wantOK:       true,
+		},
+		{
+			wastePercent: 15,
+			lengths:      cuts,
+			want:         460 * Centimeter,
+			wantOK:       true,
+		},
+		{
+			wastePercent: 15,
+			lengths:      nil,
+			want:         0,
+			wantOK:       true,
+		},
+		{
+			wastePercent: -5,
+			lengths:      cuts,
+			want:         0,
+			wantOK:       false,
+		},
+		{
+			wastePercent: 15,
+			lengths:      []Length{MaxLength / 2, MaxLength / 2},
+			want:         0,
+			wantOK:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotOK := TotalWithWaste(tc.wastePercent, tc.lengths...)
+		if got != tc.want || gotOK != tc.wantOK {
+			t.Errorf(
+				"TotalWithWaste(%v): got %q, %t, want %q, %t",
+				tc.wastePercent,
+				got,
+				gotOK,
+				tc.want,
+				tc.wantOK,
+			)
+		}
+	}
+}
+
+func TestGCD(t *testing.T) {
+	testCases := []struct {
+		lengths []Length
+		want    Length
+	}{
+		{
+			lengths: nil,
+			want:    0,
+		},
+		{
+			lengths: []Length{0, 0},
+			want:    0,
+		},
+		{
+			lengths: []Length{0, 15 * Centimeter},
+			want:    15 * Centimeter,
+		},
+		{
+			lengths: []Length{40 * Centimeter, 60 * Centimeter, 1 * Meter},
+			want:    20 * Centimeter,
+		},
+		{
+			lengths: []Length{Inch, Centimeter},
+			want:    200 * Micrometer,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := GCD(tc.lengths...); got != tc.want {
+			t.Errorf("GCD(%v): got %q, want %q", tc.lengths, got, tc.want)
+		}
+	}
+}
+
+func TestLCM(t *testing.T) {
+	testCases := []struct {
+		lengths []Length
+		want    Length
+		wantOK  bool
+	}{
+		{
+			lengths: nil,
+			want:    0,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{0, 15 * Centimeter},
+			want:    0,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{15 * Centimeter},
+			want:    15 * Centimeter,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{40 * Centimeter, 60 * Centimeter},
+			want:    120 * Centimeter,
+			wantOK:  true,
+		},
+		{
+			// Coprime periods.
+			lengths: []Length{7 * Nanometer, 11 * Nanometer},
+			want:    77 * Nanometer,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{Inch, Centimeter},
+			want:    127 * Centimeter,
+			wantOK:  true,
+		},
+		{
+			lengths: []Length{MaxLength, MaxLength - 1},
+			want:    0,
+			wantOK:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotOK := LCM(tc.lengths...)
+		if got != tc.want || gotOK != tc.wantOK {
+			t.Errorf("LCM(%v): got %q, %t, want %q, %t", tc.lengths, got, gotOK, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestQuantizeSensor(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		increment Length
+		offset    Length
+		want      Length
+	}{
+		{
+			l:         0,
+			increment: Millimeter,
+			offset:    Millimeter / 2,
+			want:      Millimeter / 2,
+		},
+		{
+			l:         1234 * Micrometer,
+			increment: Millimeter,
+			offset:    Millimeter / 2,
+			want:      1500 * Micrometer,
+		},
+		{
+			l:         1999 * Micrometer,
+			increment: Millimeter,
+			offset:    Millimeter / 2,
+			want:      1500 * Micrometer,
+		},
+		{
+			l:         2000 * Micrometer,
+			increment: Millimeter,
+			offset:    Millimeter / 2,
+			want:      2500 * Micrometer,
+		},
+		{
+			l:         1234 * Micrometer,
+			increment: Millimeter,
+			offset:    0,
+			want:      1 * Millimeter,
+		},
+		{
+			l:         1234 * Micrometer,
+			increment: Millimeter,
+			offset:    7500 * Micrometer,
+			want:      1500 * Micrometer,
+		},
+		{
+			l:         1234 * Micrometer,
+			increment: 0,
+			offset:    Millimeter / 2,
+			want:      1234 * Micrometer,
+		},
+		{
+			l:         MaxLength,
+			increment: 10 * Nanometer,
+			offset:    0,
+			want:      MaxLength - 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.QuantizeSensor(tc.increment, tc.offset); got != tc.want {
+			t.Errorf(
+				"QuantizeSensor(%q, %q, %q): got %q, want %q",
+				tc.l,
+				tc.increment,
+				tc.offset,
+				got,
+				tc.want,
+			)
+		}
+	}
+}
+
+func TestRatio(t *testing.T) {
+	testCases := []struct {
+		a    Length
+		b    Length
+		want float64
+	}{
+		{
+			a:    0,
+			b:    80 * Centimeter,
+			want: 0,
+		},
+		{
+			a:    120 * Centimeter,
+			b:    80 * Centimeter,
+			want: 1.5,
+		},
+		{
+			a:    70 * Centimeter,
+			b:    100 * Centimeter,
+			want: 0.7,
+		},
+		{
+			a:    178 * Centimeter,
+			b:    178 * Centimeter,
+			want: 1,
+		},
+		{
+			a:    120 * Centimeter,
+			b:    0,
+			want: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := Ratio(tc.a, tc.b); !floatEqual(got, tc.want) {
+			t.Errorf("Ratio(%q, %q): got %f, want %f", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestPercentDiff(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		reference Length
+		want      float64
+	}{
+		{
+			l:         178 * Centimeter,
+			reference: 178 * Centimeter,
+			want:      0,
+		},
+		{
+			l:         105 * Centimeter,
+			reference: 100 * Centimeter,
+			want:      5,
+		},
+		{
+			l:         95 * Centimeter,
+			reference: 100 * Centimeter,
+			want:      -5,
+		},
+		{
+			l:         0,
+			reference: 100 * Centimeter,
+			want:      -100,
+		},
+		{
+			l:         MaxLength,
+			reference: MaxLength - 1,
+			want:      0,
+		},
+		{
+			l:         100 * Centimeter,
+			reference: 0,
+			want:      0,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.PercentDiff(tc.reference); !floatEqual(got, tc.want) {
+			t.Errorf("PercentDiff(%q, %q): got %f, want %f", tc.l, tc.reference, got, tc.want)
+		}
+	}
+}
+
+func TestPercentOf(t *testing.T) {
+	testCases := []struct {
+		l     Length
+		whole Length
+		want  float64
+	}{
+		{
+			l:     0,
+			whole: 178 * Centimeter,
+			want:  0,
+		},
+		{
+			l:     89 * Centimeter,
+			whole: 178 * Centimeter,
+			want:  50,
+		},
+		{
+			l:     2 * Meter,
+			whole: 1 * Meter,
+			want:  200,
+		},
+		{
+			l:     1 * Meter,
+			whole: 0,
+			want:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.PercentOf(tc.whole); !floatEqual(got, tc.want) {
+			t.Errorf("PercentOf(%q, %q): got %f, want %f", tc.l, tc.whole, got, tc.want)
+		}
+	}
+}
+
+func TestWithinPercent(t *testing.T) {
+	testCases := []struct {
+		l     Length
+		other Length
+		want  bool
+	}{
+		{
+			l:     0,
+			other: 0,
+			want:  true,
+		},
+		{
+			l:     178 * Centimeter,
+			other: 178 * Centimeter,
+			want:  true,
+		},
+		{
+			l:     100 * Centimeter,
+			other: 99 * Centimeter,
+			want:  true,
+		},
+		{
+			l:     99 * Centimeter,
+			other: 100 * Centimeter,
+			want:  true,
+		},
+		{
+			l:     100 * Centimeter,
+			other: 98 * Centimeter,
+			want:  false,
+		},
+		{
+			l:     1 * Nanometer,
+			other: 0,
+			want:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.WithinPercent(tc.other, 1); got != tc.want {
+			t.Errorf("WithinPercent(%q, %q, 1): got %t, want %t", tc.l, tc.other, got, tc.want)
+		}
+	}
+	if (178 * Centimeter).WithinPercent(178*Centimeter, -1) {
+		t.Errorf("WithinPercent(-1): got true, want false")
+	}
+}
+
+func TestWeightedMean(t *testing.T) {
+	testCases := []struct {
+		lengths []Length
+		weights []float64
+		want    Length
+	}{
+		{
+			lengths: []Length{178 * Centimeter},
+			weights: []float64{0.5},
+			want:    178 * Centimeter,
+		},
+		{
+			// (170*1 + 180*3) / 4 = 177.5
+			lengths: []Length{170 * Centimeter, 180 * Centimeter},
+			weights: []float64{1, 3},
+			want:    1775 * Millimeter,
+		},
+		{
+			// (1*0.2 + 2*0.3 + 4*0.5) / 1 = 2.8
+			lengths: []Length{1 * Meter, 2 * Meter, 4 * Meter},
+			weights: []float64{0.2, 0.3, 0.5},
+			want:    2800 * Millimeter,
+		},
+		{
+			lengths: []Length{1 * Meter, 2 * Meter},
+			weights: []float64{0, 1},
+			want:    2 * Meter,
+		},
+		{
+			lengths: []Length{MaxLength, MaxLength},
+			weights: []float64{1, 1},
+			want:    MaxLength,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := WeightedMean(tc.lengths, tc.weights)
+		if err != nil || got != tc.want {
+			t.Errorf("WeightedMean(%v, %v): got %q, %v, want %q", tc.lengths, tc.weights, got, err, tc.want)
+		}
+	}
+}
+
+func TestWeightedMeanErrors(t *testing.T) {
+	testCases := []struct {
+		lengths []Length
+		weights []float64
+	}{
+		{
+			lengths: []Length{1 * Meter, 2 * Meter},
+			weights: []float64{1},
+		},
+		{
+			lengths: nil,
+			weights: nil,
+		},
+		{
+			lengths: []Length{1 * Meter, 2 * Meter},
+			weights: []float64{0, 0},
+		},
+		{
+			lengths: []Length{1 * Meter, 2 * Meter},
+			weights: []float64{-1, 2},
+		},
+		{
+			lengths: []Length{1 * Meter},
+			weights: []float64{math.NaN()},
+		},
+		{
+			lengths: []Length{1 * Meter, 2 * Meter},
+			weights: []float64{math.Inf(1), 1},
+		},
+		{
+			// The weighted sum overflows.
+			lengths: []Length{1 * Meter, 2 * Meter},
+			weights: []float64{math.MaxFloat64 / 2, math.MaxFloat64 / 2},
+		},
+		{
+			// The total weight overflows.
+			lengths: []Length{0, 0},
+			weights: []float64{math.MaxFloat64, math.MaxFloat64},
+		},
+	}
+
+	for _, tc := range testCases {
+		if got, err := WeightedMean(tc.lengths, tc.weights); err == nil {
+			t.Errorf("WeightedMean(%v, %v): got %q, want error", tc.lengths, tc.weights, got)
+		}
+	}
+}
+
+func TestFits(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		piece     Length
+		wantCount uint64
+		wantWaste string
+	}{
+		{l: 2 * Meter, piece: 30 * Centimeter, wantCount: 6, wantWaste: "20cm"},
+		{l: 2 * Meter, piece: 25 * Centimeter, wantCount: 8, wantWaste: "0"},
+		{l: 2 * Meter, piece: 3 * Meter, wantCount: 0, wantWaste: "2m"},
+		{l: 2 * Meter, piece: 0, wantCount: 0, wantWaste: "2m"},
+		{l: 8 * Foot, piece: 14 * Inch, wantCount: 6, wantWaste: "30.48cm"},
+	}
+
+	for _, tc := range testCases {
+		gotCount, gotWaste := tc.l.Fits(tc.piece)
+		if gotCount != tc.wantCount || gotWaste != tc.wantWaste {
+			t.Errorf("Fits(%q, %q): got %d, %q, want %d, %q", tc.l, tc.piece, gotCount, gotWaste, tc.wantCount, tc.wantWaste)
+		}
+	}
+}
+
+func TestLayoutSpan(t *testing.T) {
+	testCases := []struct {
+		itemSize Length
+		gap      Length
+		count    int
+		want     Length
+		wantOK   bool
+	}{
+		{itemSize: 30 * Centimeter, gap: 5 * Centimeter, count: 0, want: 0, wantOK: true},
+		{itemSize: 30 * Centimeter, gap: 5 * Centimeter, count: -1, want: 0, wantOK: true},
+		{itemSize: 30 * Centimeter, gap: 5 * Centimeter, count: 1, want: 30 * Centimeter, wantOK: true},
+		{itemSize: 30 * Centimeter, gap: 5 * Centimeter, count: 4, want: 135 * Centimeter, wantOK: true},
+		{itemSize: 30 * Centimeter, gap: 0, count: 4, want: 120 * Centimeter, wantOK: true},
+		{itemSize: MaxLength / 2, gap: 1, count: 2, want: MaxLength, wantOK: true},
+		{itemSize: MaxLength / 2, gap: 2, count: 2, want: 0, wantOK: false},
+		{itemSize: MaxLength / 2, gap: 0, count: 3, want: 0, wantOK: false},
+		{itemSize: 1, gap: MaxLength / 2, count: 4, want: 0, wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := LayoutSpan(tc.itemSize, tc.gap, tc.count)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("LayoutSpan(%q, %q, %d): got %q, %t, want %q, %t", tc.itemSize, tc.gap, tc.count, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestItemsInSpan(t *testing.T) {
+	testCases := []struct {
+		available Length
+		itemSize  Length
+		gap       Length
+		want      int
+	}{
+		{available: 135 * Centimeter, itemSize: 30 * Centimeter, gap: 5 * Centimeter, want: 4},
+		{available: 150 * Centimeter, itemSize: 30 * Centimeter, gap: 5 * Centimeter, want: 4},
+		{available: 134 * Centimeter, itemSize: 30 * Centimeter, gap: 5 * Centimeter, want: 3},
+		{available: 30 * Centimeter, itemSize: 30 * Centimeter, gap: 5 * Centimeter, want: 1},
+		{available: 29 * Centimeter, itemSize: 30 * Centimeter, gap: 5 * Centimeter, want: 0},
+		{available: 120 * Centimeter, itemSize: 30 * Centimeter, gap: 0, want: 4},
+		{available: 10 * Centimeter, itemSize: 0, gap: 5 * Centimeter, want: 3},
+		{available: 10 * Centimeter, itemSize: 0, gap: 0, want: math.MaxInt},
+		{available: MaxLength, itemSize: MaxLength / 2, gap: MaxLength, want: 1},
+		{available: MaxLength, itemSize: 1, gap: 0, want: math.MaxInt},
+	}
+
+	for _, tc := range testCases {
+		if got := ItemsInSpan(tc.available, tc.itemSize, tc.gap); got != tc.want {
+			t.Errorf("ItemsInSpan(%q, %q, %q): got %d, want %d", tc.available, tc.itemSize, tc.gap, got, tc.want)
+		}
+		if tc.want == 0 || tc.want == math.MaxInt {
+			continue
+		}
+		if span, ok := LayoutSpan(tc.itemSize, tc.gap, tc.want); !ok || span > tc.available {
+			t.Errorf("LayoutSpan(%q, %q, %d): got %q, %t, want at most %q", tc.itemSize, tc.gap, tc.want, span, ok, tc.available)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package lengths
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// AppendUvarint appends the length to b as a uvarint nanometer count, as
+// encoded by binary.AppendUvarint, and returns the extended buffer. Unlike a
+// fixed 8-byte encoding, short lengths take little space: up to 127nm takes
+// 1 byte, up to 16.383μm 2 bytes and up to 34.359738367m 5 bytes.
+func (l Length) AppendUvarint(b []byte) []byte {
+	return binary.AppendUvarint(b, uint64(l))
+}
+
+// ReadUvarint decodes a length encoded by AppendUvarint from b and returns
+// the length and the number of bytes read (> 0). If an error occurred, the
+// length is zero and the number of bytes n is <= 0 with the same meaning as
+// for binary.Uvarint:
+//
+//	n == 0: buf too small
+//	n  < 0: value larger than 64 bits (overflow)
+//	        and -n is the number of bytes read
+func ReadUvarint(b []byte) (Length, int) {
+	n, i := binary.Uvarint(b)
+	return Length(n), i
+}
+
+// MarshalDelta encodes the lengths compactly as the uvarint of the first
+// length followed by the zigzag varints of the differences between successive
+// lengths. Sorted or slowly varying series, where differences are small,
+// shrink to a fraction of a fixed 8-byte encoding. Unsorted series are
+// supported too. An empty slice encodes to no bytes.
+func (ls Lengths) MarshalDelta() []byte {
+	if len(ls) == 0 {
+		return nil
+	}
+	b := ls[0].AppendUvarint(nil)
+	for i := 1; i < len(ls); i++ {
+		// The wrapping difference is undone exactly by UnmarshalDelta.
+		b = binary.AppendVarint(b, int64(ls[i]-ls[i-1]))
+	}
+	return b
+}
+
+// UnmarshalDelta decodes lengths encoded by MarshalDelta.
+func UnmarshalDelta(b []byte) (Lengths, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	first, n := ReadUvarint(b)
+	if n <= 0 {
+		return nil, errors.New("lengths: decoding delta: invalid first length")
+	}
+	ls := Lengths{first}
+	for b = b[n:]; len(b) > 0; b = b[n:] {
+		var d int64
+		d, n = binary.Varint(b)
+		if n <= 0 {
+			return nil, errors.New("lengths: decoding delta: invalid difference")
+		}
+		ls = append(ls, ls[len(ls)-1]+Length(d))
+	}
+	return ls, nil
+}
+
+// binaryVersion is the version of the layout written by MarshalBinary.
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler, used by encoding/gob
+// among others. The layout is versioned so that encoded lengths remain
+// readable if the representation of Length ever changes. Version 1 is:
+//
+//	byte 0:     version, 1
+//	bytes 1...: nanometer count as a uvarint, as written by AppendUvarint
+//
+// 178cm thus encodes to 6 bytes.
+//
+// Encoders that prefer encoding.BinaryMarshaler to the underlying integer,
+// such as encoding/gob and github.com/fxamacker/cbor/v2, encode lengths as
+// these bytes rather than as nanometer counts: CBOR encodes 178cm as the
+// byte string 46 01 80 ca e2 d0 06, not the unsigned integer 1a 6a 18 a5 00.
+// Use lengthscbor.Length to keep the integer encoding in CBOR.
+func (l Length) MarshalBinary() ([]byte, error) {
+	return l.AppendUvarint([]byte{binaryVersion}), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for lengths encoded
+// by MarshalBinary. It returns an error for unknown versions.
+func (l *Length) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("lengths: decoding binary: no data")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("lengths: decoding binary: unsupported version %d", data[0])
+	}
+	v, n := ReadUvarint(data[1:])
+	if n <= 0 || n != len(data)-1 {
+		return errors.New("lengths: decoding binary: invalid nanometer count")
+	}
+	*l = v
+	return nil
+}
@@ -0,0 +1,192 @@
+package lengths
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestUvarint(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		wantBytes int
+	}{
+		{
+			l:         0,
+			wantBytes: 1,
+		},
+		{
+			l:         127 * Nanometer,
+			wantBytes: 1,
+		},
+		{
+			l:         128 * Nanometer,
+			wantBytes: 2,
+		},
+		{
+			l:         16383 * Nanometer,
+			wantBytes: 2,
+		},
+		{
+			l:         178 * Centimeter,
+			wantBytes: 5,
+		},
+		{
+			l:         MaxLength,
+			wantBytes: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		b := tc.l.AppendUvarint([]byte{0xff})
+		if len(b)-1 != tc.wantBytes {
+			t.Errorf("AppendUvarint(%q): got %d bytes, want %d", tc.l, len(b)-1, tc.wantBytes)
+		}
+		got, n := ReadUvarint(b[1:])
+		if got != tc.l || n != tc.wantBytes {
+			t.Errorf("ReadUvarint(%x): got %q, %d, want %q, %d", b[1:], got, n, tc.l, tc.wantBytes)
+		}
+	}
+}
+
+func TestReadUvarintErrors(t *testing.T) {
+	testCases := []struct {
+		b     []byte
+		wantN int
+	}{
+		{
+			b:     nil,
+			wantN: 0,
+		},
+		{
+			b:     []byte{0x80, 0x80},
+			wantN: 0,
+		},
+		{
+			b:     []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02},
+			wantN: -10,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, n := ReadUvarint(tc.b)
+		if got != 0 || n != tc.wantN {
+			t.Errorf("ReadUvarint(%x): got %q, %d, want 0, %d", tc.b, got, n, tc.wantN)
+		}
+	}
+}
+
+func TestMarshalDelta(t *testing.T) {
+	testCases := []Lengths{
+		nil,
+		{178 * Centimeter},
+		{
+			1000 * Millimeter,
+			1002 * Millimeter,
+			1003 * Millimeter,
+			1003 * Millimeter,
+			1010 * Millimeter,
+			1011 * Millimeter,
+		},
+		{3 * Meter, 1 * Meter, 2 * Meter},
+		{0, MaxLength, 0},
+	}
+
+	for _, ls := range testCases {
+		b := ls.MarshalDelta()
+		got, err := UnmarshalDelta(b)
+		if err != nil {
+			t.Errorf("UnmarshalDelta(%x): got error %v", b, err)
+			continue
+		}
+		if len(got) != len(ls) {
+			t.Errorf("UnmarshalDelta(%x): got %v, want %v", b, got, ls)
+			continue
+		}
+		for i := range ls {
+			if got[i] != ls[i] {
+				t.Errorf("UnmarshalDelta(%x): got %v, want %v", b, got, ls)
+				break
+			}
+		}
+	}
+}
+
+func TestMarshalDeltaSize(t *testing.T) {
+	// A growth series sampled every millimeter.
+	ls := make(Lengths, 100)
+	for i := range ls {
+		ls[i] = 150*Centimeter + Length(i)*Millimeter
+	}
+
+	b := ls.MarshalDelta()
+	// 5 bytes for the first length and 3 bytes for each 1mm difference.
+	if want := 5 + 99*3; len(b) != want {
+		t.Errorf("MarshalDelta(): got %d bytes, want %d", len(b), want)
+	}
+	if fixed := 8 * len(ls); len(b) >= fixed/2 {
+		t.Errorf("MarshalDelta(): got %d bytes, want less than half of %d", len(b), fixed)
+	}
+}
+
+func TestUnmarshalDeltaErrors(t *testing.T) {
+	testCases := [][]byte{
+		{0x80},
+		{0x01, 0x80},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+	}
+
+	for _, b := range testCases {
+		if got, err := UnmarshalDelta(b); err == nil {
+			t.Errorf("UnmarshalDelta(%x): got %v, want error", b, got)
+		}
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want []byte
+	}{
+		{l: 0, want: []byte{1, 0}},
+		{l: 127, want: []byte{1, 127}},
+		{l: 178 * Centimeter, want: []byte{1, 0x80, 0xca, 0xe2, 0xd0, 0x06}},
+		{l: MaxLength, want: []byte{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+
+	for _, tc := range testCases {
+		b, err := tc.l.MarshalBinary()
+		if err != nil || !bytes.Equal(b, tc.want) {
+			t.Errorf("MarshalBinary(%q): got %x, %v, want %x", tc.l, b, err, tc.want)
+			continue
+		}
+		var got Length
+		if err := got.UnmarshalBinary(b); err != nil || got != tc.l {
+			t.Errorf("UnmarshalBinary(%x): got %q, %v, want %q", b, got, err, tc.l)
+		}
+	}
+
+	for _, b := range [][]byte{nil, {1}, {2, 0}, {1, 0, 0}, {1, 0x80}} {
+		var got Length
+		if err := got.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%x): got %q, want error", b, got)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type measurement struct {
+		Name   string
+		Height Length
+	}
+	want := measurement{Name: "height", Height: 178 * Centimeter}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatalf("Encode(%v): %v", want, err)
+	}
+	var got measurement
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil || got != want {
+		t.Errorf("Decode(): got %v, %v, want %v", got, err, want)
+	}
+}
@@ -0,0 +1,16 @@
+package lengths
+
+// MinHeight and MaxHeight bound the human heights considered plausible by
+// IsPlausibleHeight. They default to 50cm, below the height of most
+// newborns, and 272cm, the tallest recorded human height, and may be
+// overridden by applications with different needs.
+var (
+	MinHeight = 50 * Centimeter
+	MaxHeight = 272 * Centimeter
+)
+
+// IsPlausibleHeight reports whether the length is a realistic human height,
+// that is whether it lies between MinHeight and MaxHeight, both included.
+func (l Length) IsPlausibleHeight() bool {
+	return MinHeight <= l && l <= MaxHeight
+}
@@ -0,0 +1,59 @@
+package lengths
+
+import "testing"
+
+func TestIsPlausibleHeight(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want bool
+	}{
+		{
+			l:    0,
+			want: false,
+		},
+		{
+			l:    MinHeight - Nanometer,
+			want: false,
+		},
+		{
+			l:    50 * Centimeter,
+			want: true,
+		},
+		{
+			l:    178 * Centimeter,
+			want: true,
+		},
+		{
+			l:    272 * Centimeter,
+			want: true,
+		},
+		{
+			l:    MaxHeight + Nanometer,
+			want: false,
+		},
+		{
+			l:    178 * Meter,
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.IsPlausibleHeight(); got != tc.want {
+			t.Errorf("IsPlausibleHeight(%q): got %t, want %t", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestIsPlausibleHeightOverride(t *testing.T) {
+	defer func(min, max Length) {
+		MinHeight, MaxHeight = min, max
+	}(MinHeight, MaxHeight)
+
+	MinHeight, MaxHeight = 1*Meter, 2*Meter
+	if (90 * Centimeter).IsPlausibleHeight() {
+		t.Errorf("IsPlausibleHeight(90cm): got true, want false")
+	}
+	if !(2 * Meter).IsPlausibleHeight() {
+		t.Errorf("IsPlausibleHeight(2m): got false, want true")
+	}
+}
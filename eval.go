@@ -0,0 +1,223 @@
+package lengths
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// A quantity is an intermediate value of an expression evaluated by Eval:
+// either a signed number of nanometers or a plain number.
+type quantity struct {
+	v        *big.Rat
+	isLength bool
+}
+
+// An evaluator evaluates the tokens of an expression by recursive descent.
+type evaluator struct {
+	tokens []string
+	expr   string
+}
+
+// Eval evaluates a small arithmetic expression of lengths and numbers, such
+// as "2m + 3ft - 4in" or "3 * 25cm", as found in garment-spec formulas, and
+// returns the resulting length, rounded to the closest nanometer. Numbers are
+// plain decimals, possibly in scientific notation, and lengths are a single
+// number followed by a unit symbol accepted by ParseUnit, such as "3ft" or
+// "2.5 m". Compound lengths such as "5ft 10in" must be written as sums.
+//
+// Expressions may add and subtract lengths, negate them with a leading '-',
+// multiply a length by a number, divide a length by a number or by a length,
+// and group terms with parentheses. Intermediate values are exact and may be
+// negative, but the result must be a representable length. As '/' and '-'
+// are operators, lengths in expressions cannot use fractional notations such
+// as "3 1/2in": write "(3 + 1/2) * 1in" instead.
+func Eval(expr string) (Length, error) {
+	e := evaluator{expr: expr}
+	for s := expr; s != ""; {
+		i := strings.IndexAny(s, "+-*/()")
+		for i > 0 && isExponentSign(s, i) {
+			// The sign of an exponent, as in 2.54e-3m, is not an operator.
+			j := strings.IndexAny(s[i+1:], "+-*/()")
+			if j == -1 {
+				i = -1
+				break
+			}
+			i += 1 + j
+		}
+		if i == -1 {
+			i = len(s)
+		}
+		if i == 0 {
+			i = 1
+		}
+		if tok := strings.TrimSpace(s[:i]); tok != "" {
+			e.tokens = append(e.tokens, tok)
+		}
+		s = s[i:]
+	}
+
+	q, err := e.sum()
+	if err != nil {
+		return 0, err
+	}
+	if len(e.tokens) != 0 {
+		return 0, e.errorf("unexpected " + strconv.Quote(e.tokens[0]))
+	}
+	if !q.isLength {
+		return 0, e.errorf("result is not a length")
+	}
+	if q.v.Sign() < 0 {
+		return 0, e.errorf("negative result")
+	}
+
+	// Round half up to the closest nanometer.
+	n, r := new(big.Int).QuoRem(q.v.Num(), q.v.Denom(), new(big.Int))
+	if r.Lsh(r, 1).Cmp(q.v.Denom()) >= 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	if !n.IsUint64() {
+		return 0, e.errorf("result overflows")
+	}
+	return Length(n.Uint64()), nil
+}
+
+func (e *evaluator) errorf(msg string) error {
+	return errors.New("lengths: " + msg + " in expression " + strconv.Quote(e.expr))
+}
+
+// fail returns an error for the operand tok, wrapping err, one of the errors
+// of ParseError.
+func (e *evaluator) fail(err error, tok string) error {
+	return fmt.Errorf("lengths: %w %q in expression %q", err, tok, e.expr)
+}
+
+// next consumes the next token if it is one of ops.
+func (e *evaluator) next(ops ...string) (string, bool) {
+	if len(e.tokens) == 0 {
+		return "", false
+	}
+	for _, op := range ops {
+		if e.tokens[0] == op {
+			e.tokens = e.tokens[1:]
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// sum evaluates terms separated by '+' and '-'.
+func (e *evaluator) sum() (quantity, error) {
+	q, err := e.product()
+	if err != nil {
+		return quantity{}, err
+	}
+	for {
+		op, ok := e.next("+", "-")
+		if !ok {
+			return q, nil
+		}
+		r, err := e.product()
+		if err != nil {
+			return quantity{}, err
+		}
+		if q.isLength != r.isLength {
+			return quantity{}, e.errorf("cannot add a length and a number")
+		}
+		if op == "+" {
+			q.v.Add(q.v, r.v)
+		} else {
+			q.v.Sub(q.v, r.v)
+		}
+	}
+}
+
+// product evaluates factors separated by '*' and '/'.
+func (e *evaluator) product() (quantity, error) {
+	q, err := e.factor()
+	if err != nil {
+		return quantity{}, err
+	}
+	for {
+		op, ok := e.next("*", "/")
+		if !ok {
+			return q, nil
+		}
+		r, err := e.factor()
+		if err != nil {
+			return quantity{}, err
+		}
+		if op == "*" {
+			if q.isLength && r.isLength {
+				return quantity{}, e.errorf("cannot multiply lengths")
+			}
+			q.v.Mul(q.v, r.v)
+			q.isLength = q.isLength || r.isLength
+			continue
+		}
+		if r.v.Sign() == 0 {
+			return quantity{}, e.errorf("division by zero")
+		}
+		if !q.isLength && r.isLength {
+			return quantity{}, e.errorf("cannot divide a number by a length")
+		}
+		q.v.Quo(q.v, r.v)
+		q.isLength = q.isLength && !r.isLength
+	}
+}
+
+// factor evaluates a signed factor, a parenthesized expression, a length or
+// a number.
+func (e *evaluator) factor() (quantity, error) {
+	if op, ok := e.next("-", "+"); ok {
+		q, err := e.factor()
+		if err != nil {
+			return quantity{}, err
+		}
+		if op == "-" {
+			q.v.Neg(q.v)
+		}
+		return q, nil
+	}
+	if _, ok := e.next("("); ok {
+		q, err := e.sum()
+		if err != nil {
+			return quantity{}, err
+		}
+		if _, ok := e.next(")"); !ok {
+			return quantity{}, e.errorf("missing closing parenthesis")
+		}
+		return q, nil
+	}
+	if len(e.tokens) == 0 {
+		return quantity{}, e.errorf("missing operand")
+	}
+	tok := e.tokens[0]
+	if len(tok) == 1 && strings.ContainsAny(tok, "+-*/()") {
+		return quantity{}, e.errorf("unexpected " + strconv.Quote(tok))
+	}
+	e.tokens = e.tokens[1:]
+
+	d, rest, ok := (Parser{}).leadingDecimal(tok)
+	if !ok {
+		if _, _, ok := leadingInt(tok); !ok {
+			return quantity{}, e.fail(ErrRange, tok)
+		}
+		return quantity{}, e.fail(ErrSyntax, tok)
+	}
+	if d, rest, ok = d.withExponent(rest); !ok {
+		return quantity{}, e.fail(ErrRange, tok)
+	}
+	v := new(big.Rat).SetFrac(new(big.Int).SetUint64(d.frac), new(big.Int).SetUint64(d.scale))
+	v.Add(v, new(big.Rat).SetUint64(d.whole))
+	if rest == "" {
+		return quantity{v: v}, nil
+	}
+	unit, err := ParseUnit(rest)
+	if err != nil {
+		return quantity{}, e.fail(ErrUnknownUnit, strings.TrimSpace(rest))
+	}
+	return quantity{v: v.Mul(v, new(big.Rat).SetUint64(uint64(unit))), isLength: true}, nil
+}
@@ -0,0 +1,87 @@
+package lengths
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	testCases := []struct {
+		expr string
+		want Length
+	}{
+		{expr: "178cm", want: 178 * Centimeter},
+		{expr: "2m + 3ft - 4in", want: 2*Meter + 3*Foot - 4*Inch},
+		{expr: "3 * 25cm", want: 75 * Centimeter},
+		{expr: "25cm * 3", want: 75 * Centimeter},
+		{expr: "1m / 3", want: 333333333 * Nanometer},
+		{expr: "2m / 3 * 3", want: 2 * Meter},
+		{expr: "(3 + 1/2) * 1in", want: 3*Inch + Inch/2},
+		{expr: "2 * (1m + 50cm)", want: 3 * Meter},
+		{expr: "1m - 2m + 3m", want: 2 * Meter},
+		{expr: "1m / 25cm * 10cm", want: 40 * Centimeter},
+		{expr: "5' + 10\" + 1.5 * 2cm", want: 5*Foot + 10*Inch + 3*Centimeter},
+		{expr: "5 ft + 10 in - 10 in", want: 5 * Foot},
+		{expr: "-1m + 2m", want: Meter},
+		{expr: "1m - -2m", want: 3 * Meter},
+		{expr: "+1m * -2 / -4", want: 50 * Centimeter},
+		{expr: "-(1m - 3m)", want: 2 * Meter},
+		{expr: "2.54e-2m", want: Inch},
+		{expr: " 0.5 * 1nm ", want: 1 * Nanometer},
+	}
+
+	for _, tc := range testCases {
+		got, err := Eval(tc.expr)
+		if err != nil || got != tc.want {
+			t.Errorf("Eval(%q): got %q, %v, want %q", tc.expr, got, err, tc.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"3",
+		"1m + 3",
+		"2m * 3m",
+		"3 / 1m",
+		"1m / 0",
+		"1m - 2m",
+		"(1m + 2m",
+		"1m + 2m)",
+		"1m +",
+		"* 1m",
+		"1 cubit",
+		"10000000km * 2",
+		"2m 3",
+		"5'10\"",
+		"1m75cm",
+		"-1m",
+		"1m - - ",
+		"99999999999999999999m",
+	} {
+		if got, err := Eval(expr); err == nil {
+			t.Errorf("Eval(%q): got %q, want error", expr, got)
+		}
+	}
+}
+
+func TestEvalErrorsWrap(t *testing.T) {
+	testCases := []struct {
+		expr string
+		want error
+	}{
+		{expr: "1 cubit + 1m", want: ErrUnknownUnit},
+		{expr: "2m 3", want: ErrUnknownUnit},
+		{expr: "1m + 1e99m", want: ErrRange},
+		{expr: "1m + .m", want: ErrSyntax},
+	}
+	for _, tc := range testCases {
+		_, err := Eval(tc.expr)
+		if !errors.Is(err, tc.want) || !strings.Contains(err.Error(), strconv.Quote(tc.expr)) {
+			t.Errorf("Eval(%q): got error %v, want %v in expression", tc.expr, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package lengths
+
+import "flag"
+
+// Set implements flag.Value, together with String, by parsing s as done by
+// ParseLength, so that lengths can be given on the command line as in
+// -max-height=2.1m.
+func (l *Length) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
+
+// Flag defines a length flag with the specified name, default value and
+// usage string on flag.CommandLine, analogous to flag.Duration. The return
+// value is the address of a Length variable that stores the value of the
+// flag.
+func Flag(name string, value Length, usage string) *Length {
+	p := new(Length)
+	FlagVar(p, name, value, usage)
+	return p
+}
+
+// FlagVar is like Flag but stores the value of the flag in the variable p
+// points to.
+func FlagVar(p *Length, name string, value Length, usage string) {
+	*p = value
+	flag.CommandLine.Var(p, name, usage)
+}
@@ -0,0 +1,43 @@
+package lengths
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+var _ flag.Value = (*Length)(nil)
+
+func TestFlagSet(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	height := 2 * Meter
+	fs.Var(&height, "max-height", "maximum height")
+
+	if err := fs.Parse([]string{"-max-height=2.1m"}); err != nil || height != 210*Centimeter {
+		t.Errorf("Parse(-max-height=2.1m): got %q, %v, want 2.1m", height, err)
+	}
+	if err := fs.Parse([]string{"-max-height", `5'10"`}); err != nil || height != 70*Inch {
+		t.Errorf(`Parse(-max-height 5'10"): got %q, %v, want %q`, height, err, 70*Inch)
+	}
+	if err := fs.Parse([]string{"-max-height=tall"}); err == nil {
+		t.Errorf("Parse(-max-height=tall): got %q, want error", height)
+	}
+	if got, want := fs.Lookup("max-height").DefValue, "2m"; got != want {
+		t.Errorf("DefValue: got %q, want %q", got, want)
+	}
+}
+
+func TestFlag(t *testing.T) {
+	saved := flag.CommandLine
+	defer func() { flag.CommandLine = saved }()
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+
+	p := Flag("max-height", 2*Meter, "maximum height")
+	if *p != 2*Meter {
+		t.Errorf("Flag(): got %q, want 2m", *p)
+	}
+	if err := flag.CommandLine.Parse([]string{"-max-height=1.5m"}); err != nil || *p != 150*Centimeter {
+		t.Errorf("Parse(-max-height=1.5m): got %q, %v, want 1.5m", *p, err)
+	}
+}
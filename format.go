@@ -0,0 +1,348 @@
+package lengths
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// unitSymbols maps the common length units to the symbol used when
+// formatting them.
+var unitSymbols = map[Length]string{
+	Nanometer:  "nm",
+	Micrometer: "μm",
+	Millimeter: "mm",
+	Centimeter: "cm",
+	Decimeter:  "dm",
+	Meter:      "m",
+	Kilometer:  "km",
+	Inch:       "in",
+	Foot:       "ft",
+}
+
+// UnitSymbol returns the symbol used when formatting unit, such as "cm" for
+// Centimeter. ok is false if unit is not one of the common length units.
+func UnitSymbol(unit Length) (symbol string, ok bool) {
+	symbol, ok = unitSymbols[unit]
+	return symbol, ok
+}
+
+// formatFloat formats f with as many decimals as needed. Numbers below one
+// always have a leading zero, as in 0.5, whatever the locale.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// in returns the length as a floating point number of the given unit.
+func (l Length) in(unit Length) float64 {
+	return float64(l/unit) + float64(l%unit)/float64(unit)
+}
+
+// autoUnit returns the metric unit used by String to display the length.
+func (l Length) autoUnit() Length {
+	switch {
+	case l < Micrometer:
+		return Nanometer
+	case l < Millimeter:
+		return Micrometer
+	case l < Centimeter:
+		return Millimeter
+	case l < Meter:
+		return Centimeter
+	case l < Kilometer:
+		return Meter
+	default:
+		return Kilometer
+	}
+}
+
+// FormatUnit returns the length formatted in the given unit, which must be
+// one of the common length units, with as many decimals as needed:
+//
+//	fmt.Print((178 * lengths.Centimeter).FormatUnit(lengths.Meter)) // prints 1.78m
+//
+// Lengths shorter than one unit have a leading zero, as in 0.5cm. If unit is
+// not a common length unit, FormatUnit falls back to String.
+func (l Length) FormatUnit(unit Length) string {
+	symbol, ok := unitSymbols[unit]
+	if !ok {
+		return l.String()
+	}
+	return formatFloat(l.in(unit)) + symbol
+}
+
+// SI returns the length as a number of the metric unit String would use and
+// the symbol of that unit, so that callers can format the number themselves:
+//
+//	value, symbol := (178 * lengths.Centimeter).SI() // 1.78, "m"
+//
+// A zero length is returned in nanometers.
+func (l Length) SI() (value float64, symbol string) {
+	unit := l.autoUnit()
+	return l.in(unit), unitSymbols[unit]
+}
+
+// ScientificMeters returns the length in meters in scientific notation with
+// the given number of mantissa digits after the decimal point, regardless of
+// the unit String would use:
+//
+//	fmt.Print((178 * lengths.Centimeter).ScientificMeters(2)) // prints 1.78e+00
+//
+// A negative number of digits uses the smallest number of digits necessary
+// to represent the length exactly.
+func (l Length) ScientificMeters(digits int) string {
+	return strconv.FormatFloat(l.Meters(), 'e', digits, 64)
+}
+
+// StoriesString returns the length as an approximate whole number of
+// stories of a building, each storyHeight long, such as "~5 stories".
+func (l Length) StoriesString(storyHeight Length) string {
+	n := math.Round(l.Stories(storyHeight))
+	if n == 1 {
+		return "~1 story"
+	}
+	return "~" + formatFloat(n) + " stories"
+}
+
+// FormatPrecision returns the length formatted in the given unit, which must
+// be one of the common length units, with exactly the given number of
+// decimals. The length is rounded half to even to that precision:
+//
+//	fmt.Print((1785 * lengths.Millimeter).FormatPrecision(lengths.Centimeter, 0)) // prints 178cm
+//
+// A negative number of decimals uses as many decimals as needed, as done by
+// FormatUnit. If unit is not a common length unit, FormatPrecision falls back
+// to String.
+func (l Length) FormatPrecision(unit Length, decimals int) string {
+	symbol, ok := unitSymbols[unit]
+	if !ok {
+		return l.String()
+	}
+	return l.formatNumber(unit, decimals) + symbol
+}
+
+// formatNumber returns the length as a number of the given unit, without
+// symbol, with exactly the given number of decimals, rounded half to even, or
+// as many decimals as needed if decimals is negative.
+func (l Length) formatNumber(unit Length, decimals int) string {
+	if decimals < 0 {
+		return formatFloat(l.in(unit))
+	}
+
+	// q, r = l*10^decimals / unit, computed exactly.
+	var q, r, u big.Int
+	q.Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	q.Mul(&q, new(big.Int).SetUint64(uint64(l)))
+	u.SetUint64(uint64(unit))
+	q.QuoRem(&q, &u, &r)
+	switch r.Lsh(&r, 1).Cmp(&u) {
+	case 1:
+		q.Add(&q, big.NewInt(1))
+	case 0:
+		if q.Bit(0) == 1 {
+			q.Add(&q, big.NewInt(1))
+		}
+	}
+
+	digits := q.String()
+	if decimals == 0 {
+		return digits
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	i := len(digits) - decimals
+	return digits[:i] + "." + digits[i:]
+}
+
+// FinestExactUnit returns the largest metric unit used by String in which
+// the length is a whole number, so that it can be stored or displayed in that
+// unit without loss: Centimeter for 178cm, Millimeter for 178.5cm and
+// Nanometer for lengths that are not a whole number of micrometers. A zero
+// length returns Kilometer.
+func (l Length) FinestExactUnit() Length {
+	for _, unit := range []Length{Kilometer, Meter, Centimeter, Millimeter, Micrometer} {
+		if l%unit == 0 {
+			return unit
+		}
+	}
+	return Nanometer
+}
+
+// maxRoundUnits is the largest number of units IsRound considers round.
+const maxRoundUnits = 1000
+
+// IsRound reports whether the length is a whole number of units that is small
+// enough to read as a clean value, such as 100cm, so that UIs can highlight
+// it. A number of units is small enough up to 1000: 100cm is round, while
+// 178.3cm is not and neither is 12345cm, which would rather read as 123.45m.
+// It returns false if unit is zero.
+func (l Length) IsRound(unit Length) bool {
+	return unit != 0 && l%unit == 0 && l/unit <= maxRoundUnits
+}
+
+// EqualWhenDisplayed reports whether the length and other are displayed
+// identically by FormatPrecision with the given unit and decimals, even if
+// they differ by a few nanometers. It helps telling whether a displayed value
+// actually changed.
+func (l Length) EqualWhenDisplayed(other Length, unit Length, decimals int) bool {
+	return l.FormatPrecision(unit, decimals) == other.FormatPrecision(unit, decimals)
+}
+
+// Bar returns the length as a bar of l/scale '#' characters, capped at width
+// characters, followed by the length itself. The bar is padded with spaces to
+// width characters so that bars of several lengths line up:
+//
+//	fmt.Print((178 * lengths.Centimeter).Bar(20*lengths.Centimeter, 10)) // prints ########   1.78m
+//
+// A zero scale draws a full bar for any length but zero.
+func (l Length) Bar(scale Length, width int) string {
+	if width < 0 {
+		width = 0
+	}
+	n := width
+	switch {
+	case scale == 0:
+		if l == 0 {
+			n = 0
+		}
+	case l/scale < Length(width):
+		n = int(l / scale)
+	}
+	return strings.Repeat("#", n) + strings.Repeat(" ", width-n) + " " + l.String()
+}
+
+// UnitForSigFigs returns the largest metric unit used by String in which the
+// whole part of the length has at least minFigs significant figures, so that
+// the length reads with enough figures without leading zeros such as in
+// 0.003m: Centimeter for 178cm with 3 figures, Micrometer for 3mm. It returns
+// Nanometer if no unit has enough figures.
+func (l Length) UnitForSigFigs(minFigs int) Length {
+	threshold := uint64(1)
+	for i := 1; i < minFigs; i++ {
+		if threshold > uint64(MaxLength)/10 {
+			return Nanometer
+		}
+		threshold *= 10
+	}
+	for _, unit := range []Length{Kilometer, Meter, Centimeter, Millimeter, Micrometer} {
+		if uint64(l/unit) >= threshold {
+			return unit
+		}
+	}
+	return Nanometer
+}
+
+// OfString returns the length as a percentage of reference with the given
+// number of decimals, such as "47.2%". A zero reference is formatted as 0%.
+func (l Length) OfString(reference Length, decimals int) string {
+	return strconv.FormatFloat(l.PercentOf(reference), 'f', decimals, 64) + "%"
+}
+
+// StringMinUnit returns the length formatted like String but never in a unit
+// smaller than minUnit, which must be one of the common metric units. With a
+// Millimeter minUnit, as preferred for medical imaging, 50μm is formatted as
+// 0.05mm. If minUnit is not a common length unit, StringMinUnit falls back to
+// String.
+func (l Length) StringMinUnit(minUnit Length) string {
+	unit := l.autoUnit()
+	if _, ok := unitSymbols[minUnit]; !ok || l == 0 || unit >= minUnit {
+		return l.String()
+	}
+	return l.FormatUnit(minUnit)
+}
+
+// StringMaxUnit returns the length formatted like String but never in a unit
+// larger than maxUnit, which must be one of the common metric units. With a
+// Meter maxUnit, as for dashboards that never show kilometers, 5km is
+// formatted as 5000m. If maxUnit is not a common length unit, StringMaxUnit
+// falls back to String.
+func (l Length) StringMaxUnit(maxUnit Length) string {
+	unit := l.autoUnit()
+	if _, ok := unitSymbols[maxUnit]; !ok || l == 0 || unit <= maxUnit {
+		return l.String()
+	}
+	return l.FormatUnit(maxUnit)
+}
+
+// WholeUnitsRemainder returns the whole number of units in the length and the
+// remainder formatted by String, for compound displays such as odometers:
+// 7.65432m is 7 meters and 65.432cm. If unit is zero, the whole length is
+// returned as remainder.
+func (l Length) WholeUnitsRemainder(unit Length) (uint64, string) {
+	if unit == 0 {
+		return 0, l.String()
+	}
+	return uint64(l / unit), (l % unit).String()
+}
+
+// GrowthFrom returns the signed change from previous to the length as a
+// percentage with the given number of decimals, such as "+2.3%" or "-1.1%",
+// for longitudinal tracking. Changes that round to zero have no sign. As for
+// PercentDiff, a zero previous length reports no change.
+func (l Length) GrowthFrom(previous Length, decimals int) string {
+	p := l.PercentDiff(previous)
+	s := strconv.FormatFloat(math.Abs(p), 'f', decimals, 64)
+	switch {
+	case strings.Trim(s, "0.") == "":
+		return s + "%"
+	case p < 0:
+		return "-" + s + "%"
+	default:
+		return "+" + s + "%"
+	}
+}
+
+// Delta returns the signed difference a-b, such as a new length minus the
+// previous one, formatted as done by FormatPrecision with the given unit and
+// decimals, such as "+1.5cm" or "-0.3cm". Differences that display as zero
+// have no sign.
+func Delta(a, b Length, unit Length, decimals int) string {
+	m := a - b
+	if diff(a, b) < 0 {
+		m = b - a
+	}
+	s := m.FormatPrecision(unit, decimals)
+	switch {
+	case !strings.ContainsAny(s, "123456789"):
+		return s
+	case a < b:
+		return "-" + s
+	default:
+		return "+" + s
+	}
+}
+
+// goUnits lists the units GoLiteral may use, with their constant name.
+var goUnits = []struct {
+	unit Length
+	name string
+}{
+	{Kilometer, "Kilometer"},
+	{Meter, "Meter"},
+	{Foot, "Foot"},
+	{Inch, "Inch"},
+	{Centimeter, "Centimeter"},
+	{Millimeter, "Millimeter"},
+	{Micrometer, "Micrometer"},
+	{Nanometer, "Nanometer"},
+}
+
+// GoLiteral returns the length as a Go expression evaluating to it, for use
+// by code generators. The expression uses the unit constant of the package in
+// which the length is the smallest whole number, such as
+// "178 * lengths.Centimeter" for 178cm, "6 * lengths.Foot" for 6ft and
+// "1234567 * lengths.Nanometer" for 1.234567mm. A zero length returns "0".
+func (l Length) GoLiteral() string {
+	if l == 0 {
+		return "0"
+	}
+	best := goUnits[len(goUnits)-1]
+	for _, u := range goUnits {
+		if l%u.unit == 0 && u.unit > best.unit {
+			best = u
+		}
+	}
+	return strconv.FormatUint(uint64(l/best.unit), 10) + " * lengths." + best.name
+}
@@ -0,0 +1,833 @@
+package lengths
+
+import "testing"
+
+func TestFormatUnit(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		unit Length
+		want string
+	}{
+		{
+			l:    0,
+			unit: Meter,
+			want: "0m",
+		},
+		{
+			l:    178 * Centimeter,
+			unit: Meter,
+			want: "1.78m",
+		},
+		{
+			l:    178 * Centimeter,
+			unit: Millimeter,
+			want: "1780mm",
+		},
+		{
+			l:    5 * Kilometer,
+			unit: Meter,
+			want: "5000m",
+		},
+		{
+			l:    254 * Micrometer,
+			unit: Inch,
+			want: "0.01in",
+		},
+		{
+			l:    6 * Foot,
+			unit: Foot,
+			want: "6ft",
+		},
+		{
+			l:    1234 * Nanometer,
+			unit: Nanometer,
+			want: "1234nm",
+		},
+		{
+			l:    178 * Centimeter,
+			unit: 3 * Centimeter,
+			want: "1.78m",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.FormatUnit(tc.unit); got != tc.want {
+			t.Errorf("FormatUnit(): got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestUnitSymbol(t *testing.T) {
+	testCases := []struct {
+		unit       Length
+		wantSymbol string
+		wantOK     bool
+	}{
+		{unit: Nanometer, wantSymbol: "nm", wantOK: true},
+		{unit: Micrometer, wantSymbol: "μm", wantOK: true},
+		{unit: Centimeter, wantSymbol: "cm", wantOK: true},
+		{unit: Foot, wantSymbol: "ft", wantOK: true},
+		{unit: 0, wantSymbol: "", wantOK: false},
+		{unit: 3 * Meter, wantSymbol: "", wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		if symbol, ok := UnitSymbol(tc.unit); symbol != tc.wantSymbol || ok != tc.wantOK {
+			t.Errorf("UnitSymbol(%q): got %q, %t, want %q, %t", tc.unit, symbol, ok, tc.wantSymbol, tc.wantOK)
+		}
+	}
+}
+
+func TestSI(t *testing.T) {
+	testCases := []struct {
+		l          Length
+		wantValue  float64
+		wantSymbol string
+	}{
+		{l: 0, wantValue: 0, wantSymbol: "nm"},
+		{l: 250 * Nanometer, wantValue: 250, wantSymbol: "nm"},
+		{l: 12 * Micrometer, wantValue: 12, wantSymbol: "μm"},
+		{l: 4500 * Micrometer, wantValue: 4.5, wantSymbol: "mm"},
+		{l: 42 * Centimeter, wantValue: 42, wantSymbol: "cm"},
+		{l: 178 * Centimeter, wantValue: 1.78, wantSymbol: "m"},
+		{l: 42195 * Meter, wantValue: 42.195, wantSymbol: "km"},
+	}
+
+	for _, tc := range testCases {
+		gotValue, gotSymbol := tc.l.SI()
+		if gotValue != tc.wantValue || gotSymbol != tc.wantSymbol {
+			t.Errorf("SI(%q): got %g, %q, want %g, %q", tc.l, gotValue, gotSymbol, tc.wantValue, tc.wantSymbol)
+		}
+	}
+}
+
+func TestScientificMeters(t *testing.T) {
+	testCases := []struct {
+		l      Length
+		digits int
+		want   string
+	}{
+		{
+			l:      0,
+			digits: 2,
+			want:   "0.00e+00",
+		},
+		{
+			l:      178 * Centimeter,
+			digits: 2,
+			want:   "1.78e+00",
+		},
+		{
+			l:      178 * Centimeter,
+			digits: 0,
+			want:   "2e+00",
+		},
+		{
+			l:      178 * Centimeter,
+			digits: -1,
+			want:   "1.78e+00",
+		},
+		{
+			l:      1234 * Nanometer,
+			digits: 3,
+			want:   "1.234e-06",
+		},
+		{
+			l:      254 * Micrometer,
+			digits: 1,
+			want:   "2.5e-04",
+		},
+		{
+			l:      7654321 * Kilometer,
+			digits: 4,
+			want:   "7.6543e+09",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.ScientificMeters(tc.digits); got != tc.want {
+			t.Errorf("ScientificMeters(%d): got %q, want %q", tc.digits, got, tc.want)
+		}
+	}
+}
+
+func TestStoriesString(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want string
+	}{
+		{
+			l:    0,
+			want: "~0 stories",
+		},
+		{
+			l:    3500 * Millimeter,
+			want: "~1 story",
+		},
+		{
+			l:    14 * Meter,
+			want: "~5 stories",
+		},
+		{
+			l:    828 * Meter,
+			want: "~276 stories",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.StoriesString(3 * Meter); got != tc.want {
+			t.Errorf("StoriesString(): got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestFormatPrecision(t *testing.T) {
+	testCases := []struct {
+		l        Length
+		unit     Length
+		decimals int
+		want     string
+	}{
+		{
+			l:        0,
+			unit:     Centimeter,
+			decimals: 1,
+			want:     "0.0cm",
+		},
+		{
+			l:        178 * Centimeter,
+			unit:     Centimeter,
+			decimals: 1,
+			want:     "178.0cm",
+		},
+		{
+			l:        1784 * Millimeter,
+			unit:     Centimeter,
+			decimals: 1,
+			want:     "178.4cm",
+		},
+		{
+			l:        17845 * Micrometer,
+			unit:     Centimeter,
+			decimals: 1,
+			want:     "1.8cm",
+		},
+		{
+			l:        1785 * Millimeter,
+			unit:     Centimeter,
+			decimals: 0,
+			want:     "178cm",
+		},
+		{
+			l:        1775 * Millimeter,
+			unit:     Centimeter,
+			decimals: 0,
+			want:     "178cm",
+		},
+		{
+			l:        5 * Millimeter,
+			unit:     Centimeter,
+			decimals: 0,
+			want:     "0cm",
+		},
+		{
+			l:        15 * Millimeter,
+			unit:     Centimeter,
+			decimals: 0,
+			want:     "2cm",
+		},
+		{
+			l:        178 * Centimeter,
+			unit:     Inch,
+			decimals: 3,
+			want:     "70.079in",
+		},
+		{
+			l:        254 * Micrometer,
+			unit:     Inch,
+			decimals: 3,
+			want:     "0.010in",
+		},
+		{
+			l:        1 * Nanometer,
+			unit:     Meter,
+			decimals: 9,
+			want:     "0.000000001m",
+		},
+		{
+			l:        178 * Centimeter,
+			unit:     Meter,
+			decimals: -1,
+			want:     "1.78m",
+		},
+		{
+			l:        178 * Centimeter,
+			unit:     3 * Centimeter,
+			decimals: 1,
+			want:     "1.78m",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.FormatPrecision(tc.unit, tc.decimals); got != tc.want {
+			t.Errorf("FormatPrecision(%d): got %q, want %q", tc.decimals, got, tc.want)
+		}
+	}
+}
+
+func TestFinestExactUnit(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want Length
+	}{
+		{
+			l:    0,
+			want: Kilometer,
+		},
+		{
+			l:    42 * Kilometer,
+			want: Kilometer,
+		},
+		{
+			l:    2 * Meter,
+			want: Meter,
+		},
+		{
+			l:    178 * Centimeter,
+			want: Centimeter,
+		},
+		{
+			l:    1785 * Millimeter,
+			want: Millimeter,
+		},
+		{
+			l:    Inch,
+			want: Micrometer,
+		},
+		{
+			l:    1234567 * Nanometer,
+			want: Nanometer,
+		},
+		{
+			l:    1*Meter + 1*Nanometer,
+			want: Nanometer,
+		},
+		{
+			l:    2 * Nanometer,
+			want: Nanometer,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.FinestExactUnit(); got != tc.want {
+			t.Errorf("FinestExactUnit(%q): got %q, want %q", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestIsRound(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		unit Length
+		want bool
+	}{
+		{l: 100 * Centimeter, unit: Centimeter, want: true},
+		{l: 1783 * Millimeter, unit: Centimeter, want: false},
+		{l: 1783 * Millimeter, unit: Millimeter, want: false},
+		{l: 1000 * Centimeter, unit: Centimeter, want: true},
+		{l: 1001 * Centimeter, unit: Centimeter, want: false},
+		{l: 6 * Foot, unit: Inch, want: true},
+		{l: 0, unit: Centimeter, want: true},
+		{l: 100 * Centimeter, unit: 0, want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.IsRound(tc.unit); got != tc.want {
+			t.Errorf("IsRound(%q, %q): got %t, want %t", tc.l, tc.unit, got, tc.want)
+		}
+	}
+}
+
+func TestEqualWhenDisplayed(t *testing.T) {
+	testCases := []struct {
+		l     Length
+		other Length
+		want  bool
+	}{
+		{
+			l:     178 * Centimeter,
+			other: 178 * Centimeter,
+			want:  true,
+		},
+		{
+			l:     178 * Centimeter,
+			other: 178*Centimeter + 400*Micrometer,
+			want:  true,
+		},
+		{
+			l:     1784 * Millimeter,
+			other: 1786 * Millimeter,
+			want:  false,
+		},
+		{
+			l:     178*Centimeter + 4999*Micrometer,
+			other: 178*Centimeter + 5001*Micrometer,
+			want:  false,
+		},
+		{
+			l:     1775 * Millimeter,
+			other: 1785 * Millimeter,
+			want:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.EqualWhenDisplayed(tc.other, Centimeter, 0); got != tc.want {
+			t.Errorf("EqualWhenDisplayed(%q, %q): got %t, want %t", tc.l, tc.other, got, tc.want)
+		}
+	}
+}
+
+func TestBar(t *testing.T) {
+	testCases := []struct {
+		l     Length
+		scale Length
+		want  string
+	}{
+		{
+			l:     0,
+			scale: 20 * Centimeter,
+			want:  "           0",
+		},
+		{
+			l:     19 * Centimeter,
+			scale: 20 * Centimeter,
+			want:  "           19cm",
+		},
+		{
+			l:     1 * Meter,
+			scale: 20 * Centimeter,
+			want:  "#####      1m",
+		},
+		{
+			l:     178 * Centimeter,
+			scale: 20 * Centimeter,
+			want:  "########   1.78m",
+		},
+		{
+			l:     2 * Meter,
+			scale: 20 * Centimeter,
+			want:  "########## 2m",
+		},
+		{
+			l:     5 * Meter,
+			scale: 20 * Centimeter,
+			want:  "########## 5m",
+		},
+		{
+			l:     5 * Meter,
+			scale: 0,
+			want:  "########## 5m",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.Bar(tc.scale, 10); got != tc.want {
+			t.Errorf("Bar(%q, 10): got %q, want %q", tc.scale, got, tc.want)
+		}
+	}
+}
+
+func TestUnitForSigFigs(t *testing.T) {
+	testCases := []struct {
+		l       Length
+		minFigs int
+		want    Length
+	}{
+		{
+			l:       0,
+			minFigs: 3,
+			want:    Nanometer,
+		},
+		{
+			l:       12 * Nanometer,
+			minFigs: 3,
+			want:    Nanometer,
+		},
+		{
+			l:       3 * Millimeter,
+			minFigs: 3,
+			want:    Micrometer,
+		},
+		{
+			l:       178 * Centimeter,
+			minFigs: 3,
+			want:    Centimeter,
+		},
+		{
+			l:       178 * Centimeter,
+			minFigs: 1,
+			want:    Meter,
+		},
+		{
+			l:       42195 * Meter,
+			minFigs: 3,
+			want:    Meter,
+		},
+		{
+			l:       765432 * Kilometer,
+			minFigs: 3,
+			want:    Kilometer,
+		},
+		{
+			l:       MaxLength,
+			minFigs: 30,
+			want:    Nanometer,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.UnitForSigFigs(tc.minFigs); got != tc.want {
+			t.Errorf("UnitForSigFigs(%q, %d): got %q, want %q", tc.l, tc.minFigs, got, tc.want)
+		}
+	}
+}
+
+func TestOfString(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		reference Length
+		decimals  int
+		want      string
+	}{
+		{
+			l:         84 * Centimeter,
+			reference: 178 * Centimeter,
+			decimals:  0,
+			want:      "47%",
+		},
+		{
+			l:         84 * Centimeter,
+			reference: 178 * Centimeter,
+			decimals:  1,
+			want:      "47.2%",
+		},
+		{
+			l:         178 * Centimeter,
+			reference: 178 * Centimeter,
+			decimals:  1,
+			want:      "100.0%",
+		},
+		{
+			l:         84 * Centimeter,
+			reference: 0,
+			decimals:  1,
+			want:      "0.0%",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.OfString(tc.reference, tc.decimals); got != tc.want {
+			t.Errorf("OfString(%q, %d): got %q, want %q", tc.reference, tc.decimals, got, tc.want)
+		}
+	}
+}
+
+func TestStringMinUnit(t *testing.T) {
+	testCases := []struct {
+		l       Length
+		minUnit Length
+		want    string
+	}{
+		{
+			l:       0,
+			minUnit: Millimeter,
+			want:    "0",
+		},
+		{
+			l:       50000 * Nanometer,
+			minUnit: Millimeter,
+			want:    "0.05mm",
+		},
+		{
+			l:       12 * Nanometer,
+			minUnit: Millimeter,
+			want:    "0.000012mm",
+		},
+		{
+			l:       1234567 * Nanometer,
+			minUnit: Millimeter,
+			want:    "1.234567mm",
+		},
+		{
+			l:       178 * Centimeter,
+			minUnit: Millimeter,
+			want:    "1.78m",
+		},
+		{
+			l:       50000 * Nanometer,
+			minUnit: Micrometer,
+			want:    "50μm",
+		},
+		{
+			l:       50000 * Nanometer,
+			minUnit: 3 * Millimeter,
+			want:    "50μm",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.StringMinUnit(tc.minUnit); got != tc.want {
+			t.Errorf("StringMinUnit(%q): got %q, want %q", tc.minUnit, got, tc.want)
+		}
+	}
+}
+
+func TestStringMaxUnit(t *testing.T) {
+	testCases := []struct {
+		l       Length
+		maxUnit Length
+		want    string
+	}{
+		{
+			l:       0,
+			maxUnit: Meter,
+			want:    "0",
+		},
+		{
+			l:       5 * Kilometer,
+			maxUnit: Meter,
+			want:    "5000m",
+		},
+		{
+			l:       42195 * Meter,
+			maxUnit: Meter,
+			want:    "42195m",
+		},
+		{
+			l:       178 * Centimeter,
+			maxUnit: Meter,
+			want:    "1.78m",
+		},
+		{
+			l:       5 * Millimeter,
+			maxUnit: Meter,
+			want:    "5mm",
+		},
+		{
+			l:       178 * Centimeter,
+			maxUnit: Centimeter,
+			want:    "178cm",
+		},
+		{
+			l:       5 * Kilometer,
+			maxUnit: Centimeter,
+			want:    "500000cm",
+		},
+		{
+			l:       42 * Centimeter,
+			maxUnit: Centimeter,
+			want:    "42cm",
+		},
+		{
+			l:       5 * Kilometer,
+			maxUnit: 3 * Meter,
+			want:    "5km",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.StringMaxUnit(tc.maxUnit); got != tc.want {
+			t.Errorf("StringMaxUnit(%q, %q): got %q, want %q", tc.l, tc.maxUnit, got, tc.want)
+		}
+	}
+}
+
+func TestWholeUnitsRemainder(t *testing.T) {
+	testCases := []struct {
+		l             Length
+		unit          Length
+		wantWhole     uint64
+		wantRemainder string
+	}{
+		{
+			l:             7654320 * Micrometer,
+			unit:          Meter,
+			wantWhole:     7,
+			wantRemainder: "65.432cm",
+		},
+		{
+			l:             7 * Meter,
+			unit:          Meter,
+			wantWhole:     7,
+			wantRemainder: "0",
+		},
+		{
+			l:             50 * Centimeter,
+			unit:          Meter,
+			wantWhole:     0,
+			wantRemainder: "50cm",
+		},
+		{
+			l:             42195 * Meter,
+			unit:          Kilometer,
+			wantWhole:     42,
+			wantRemainder: "195m",
+		},
+		{
+			l:             7 * Meter,
+			unit:          0,
+			wantWhole:     0,
+			wantRemainder: "7m",
+		},
+	}
+
+	for _, tc := range testCases {
+		gotWhole, gotRemainder := tc.l.WholeUnitsRemainder(tc.unit)
+		if gotWhole != tc.wantWhole || gotRemainder != tc.wantRemainder {
+			t.Errorf(
+				"WholeUnitsRemainder(%q, %q): got %d, %q, want %d, %q",
+				tc.l,
+				tc.unit,
+				gotWhole,
+				gotRemainder,
+				tc.wantWhole,
+				tc.wantRemainder,
+			)
+		}
+	}
+}
+
+func TestLeadingZero(t *testing.T) {
+	l := 5 * Millimeter
+	testCases := []struct {
+		got  string
+		want string
+	}{
+		{
+			got:  l.FormatUnit(Centimeter),
+			want: "0.5cm",
+		},
+		{
+			got:  l.FormatPrecision(Centimeter, 2),
+			want: "0.50cm",
+		},
+		{
+			got:  l.StringMinUnit(Centimeter),
+			want: "0.5cm",
+		},
+		{
+			got:  (Nanometer).FormatUnit(Meter),
+			want: "0.000000001m",
+		},
+		{
+			got:  l.FormatLayout("%{cm}"),
+			want: "0.5",
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+
+	// String picks a unit in which lengths are at least one, so only zero
+	// starts with a zero.
+	for l := Nanometer; l < 10*Kilometer; l = l*3 + 7 {
+		if s := l.String(); s[0] == '0' || s[0] == '.' {
+			t.Errorf("String(%d): got %q, want no leading zero", l, s)
+		}
+	}
+}
+
+func TestGrowthFrom(t *testing.T) {
+	testCases := []struct {
+		l        Length
+		previous Length
+		decimals int
+		want     string
+	}{
+		{
+			l:        1023 * Millimeter,
+			previous: 1000 * Millimeter,
+			decimals: 1,
+			want:     "+2.3%",
+		},
+		{
+			l:        989 * Millimeter,
+			previous: 1000 * Millimeter,
+			decimals: 1,
+			want:     "-1.1%",
+		},
+		{
+			l:        1000 * Millimeter,
+			previous: 1000 * Millimeter,
+			decimals: 1,
+			want:     "0.0%",
+		},
+		{
+			l:        9999 * Micrometer,
+			previous: 10 * Millimeter,
+			decimals: 0,
+			want:     "0%",
+		},
+		{
+			l:        1 * Meter,
+			previous: 0,
+			decimals: 1,
+			want:     "0.0%",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.GrowthFrom(tc.previous, tc.decimals); got != tc.want {
+			t.Errorf("GrowthFrom(%q, %q): got %q, want %q", tc.l, tc.previous, got, tc.want)
+		}
+	}
+}
+
+func TestDelta(t *testing.T) {
+	testCases := []struct {
+		a, b Length
+		want string
+	}{
+		{a: 1795 * Millimeter, b: 178 * Centimeter, want: "+1.5cm"},
+		{a: 1777 * Millimeter, b: 178 * Centimeter, want: "-0.3cm"},
+		{a: 178 * Centimeter, b: 178 * Centimeter, want: "0.0cm"},
+		{a: 178*Centimeter + 40*Micrometer, b: 178 * Centimeter, want: "0.0cm"},
+		{a: 2 * Meter, b: 0, want: "+200.0cm"},
+		{a: 0, b: MaxLength, want: "-1844674407371.0cm"},
+	}
+
+	for _, tc := range testCases {
+		if got := Delta(tc.a, tc.b, Centimeter, 1); got != tc.want {
+			t.Errorf("Delta(%q, %q): got %q, want %q", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestGoLiteral(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want string
+	}{
+		{l: 0, want: "0"},
+		{l: 178 * Centimeter, want: "178 * lengths.Centimeter"},
+		{l: 2 * Meter, want: "2 * lengths.Meter"},
+		{l: 42195 * Meter, want: "42195 * lengths.Meter"},
+		{l: 3 * Kilometer, want: "3 * lengths.Kilometer"},
+		{l: 6 * Foot, want: "6 * lengths.Foot"},
+		{l: 70 * Inch, want: "70 * lengths.Inch"},
+		{l: 1785 * Millimeter, want: "1785 * lengths.Millimeter"},
+		{l: 12 * Micrometer, want: "12 * lengths.Micrometer"},
+		{l: 1234567 * Nanometer, want: "1234567 * lengths.Nanometer"},
+		{l: MaxLength, want: "18446744073709551615 * lengths.Nanometer"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.GoLiteral(); got != tc.want {
+			t.Errorf("GoLiteral(%q): got %q, want %q", tc.l, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package lengths
+
+import (
+	"math"
+	"math/big"
+)
+
+// Hypot returns sqrt(a²+b²), the length of the hypotenuse of a right
+// triangle whose other sides are a and b, rounded to the closest nanometer.
+// The squares are computed with arbitrary precision so that they cannot
+// overflow; if the hypotenuse itself is too long to be represented, Hypot
+// returns the longest representable length.
+func Hypot(a, b Length) Length {
+	var sum, sq big.Int
+	sum.SetUint64(uint64(a))
+	sum.Mul(&sum, &sum)
+	sq.SetUint64(uint64(b))
+	sq.Mul(&sq, &sq)
+	sum.Add(&sum, &sq)
+
+	// r is the floored root; sum is rounded up if sum-r² > r as
+	// (r+½)² = r²+r+¼ and sum is an integer.
+	r := new(big.Int).Sqrt(&sum)
+	sq.Mul(r, r)
+	if sum.Sub(&sum, &sq).Cmp(r) > 0 {
+		r.Add(r, big.NewInt(1))
+	}
+	if !r.IsUint64() {
+		return MaxLength
+	}
+	return Length(r.Uint64())
+}
+
+// Circumference returns the circumference of a circle whose diameter is the
+// length, rounded to the closest nanometer. If the circumference is too long
+// to be represented, Circumference returns the longest representable length.
+func (l Length) Circumference() Length {
+	return saturate(float64(l) * math.Pi)
+}
+
+// CircumferenceToDiameter returns the diameter of a circle whose
+// circumference is c, rounded to the closest nanometer.
+func CircumferenceToDiameter(c Length) Length {
+	return saturate(float64(c) / math.Pi)
+}
+
+// AtScale returns the length of a scale model of the length, where ratio is
+// the model to real ratio: a 1:48 scale is a ratio of 1.0/48. The model length
+// is rounded to the closest nanometer and limited to the longest
+// representable length. ratio must be positive.
+func (l Length) AtScale(ratio float64) Length {
+	return saturate(float64(l) * ratio)
+}
+
+// RealFromScale is the inverse of AtScale: it returns the real length of
+// which the length is a scale model at the given model to real ratio.
+func (l Length) RealFromScale(ratio float64) Length {
+	return saturate(float64(l) / ratio)
+}
+
+// ScaleByVolumeFactor returns the length scaled by the cube root of
+// volumeFactor, that is the new length of a dimension of an object whose
+// volume is scaled by volumeFactor while keeping its shape: doubling the
+// volume scales lengths by about 1.26. The length is rounded to the closest
+// nanometer and limited to the longest representable length. A zero,
+// negative or NaN volumeFactor returns zero.
+func (l Length) ScaleByVolumeFactor(volumeFactor float64) Length {
+	return saturate(float64(l) * math.Cbrt(volumeFactor))
+}
+
+// FromPixelsCalibrated returns the length of an object measured objectPx
+// pixels long in an image where a reference object of known length
+// referenceLength measures referencePx pixels, such as a credit card in a
+// body scan picture. The length is rounded to the closest nanometer and
+// limited to the longest representable length. It returns zero if
+// referencePx is not positive.
+func FromPixelsCalibrated(objectPx, referencePx float64, referenceLength Length) Length {
+	if !(referencePx > 0) {
+		return 0
+	}
+	return saturate(float64(referenceLength) * objectPx / referencePx)
+}
+
+// ArcLength returns the length of an arc of a circle of the given radius
+// spanning an angle in radians, rounded to the closest nanometer. Angles are
+// not wrapped: an angle beyond a full turn measures a helix-like winding, such
+// as a cable coiled several times around a drum. The sign of the angle only
+// gives the direction of the arc and is ignored. If the arc is too long to be
+// represented, ArcLength returns the longest representable length.
+func ArcLength(radius Length, radians float64) Length {
+	return saturate(float64(radius) * math.Abs(radians))
+}
+
+// ArcLengthDeg is like ArcLength but takes the angle in degrees.
+func ArcLengthDeg(radius Length, degrees float64) Length {
+	return ArcLength(radius, degrees*math.Pi/180)
+}
@@ -0,0 +1,281 @@
+package lengths
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHypot(t *testing.T) {
+	testCases := []struct {
+		a    Length
+		b    Length
+		want Length
+	}{
+		{
+			a:    0,
+			b:    0,
+			want: 0,
+		},
+		{
+			a:    3 * Meter,
+			b:    0,
+			want: 3 * Meter,
+		},
+		{
+			a:    3 * Meter,
+			b:    4 * Meter,
+			want: 5 * Meter,
+		},
+		{
+			a:    1 * Nanometer,
+			b:    1 * Nanometer,
+			want: 1 * Nanometer, // √2 rounds down
+		},
+		{
+			a:    2 * Nanometer,
+			b:    1 * Nanometer,
+			want: 2 * Nanometer, // √5 rounds down
+		},
+		{
+			a:    2 * Nanometer,
+			b:    2 * Nanometer,
+			want: 3 * Nanometer, // √8 rounds up
+		},
+		{
+			// Both squares overflow uint64.
+			a:    3e6 * Kilometer,
+			b:    4e6 * Kilometer,
+			want: 5e6 * Kilometer,
+		},
+		{
+			a:    math.MaxUint64,
+			b:    math.MaxUint64,
+			want: math.MaxUint64,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := Hypot(tc.a, tc.b); got != tc.want {
+			t.Errorf("Hypot(%q, %q): got %q, want %q", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestCircumference(t *testing.T) {
+	testCases := []struct {
+		diameter Length
+		want     Length
+	}{
+		{
+			diameter: 0,
+			want:     0,
+		},
+		{
+			diameter: 1 * Nanometer,
+			want:     3 * Nanometer,
+		},
+		{
+			diameter: 1 * Meter,
+			want:     3141592654 * Nanometer,
+		},
+		{
+			diameter: 15 * Millimeter,
+			want:     47123890 * Nanometer,
+		},
+		{
+			diameter: math.MaxUint64 / 2,
+			want:     math.MaxUint64,
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.diameter.Circumference()
+		if got != tc.want {
+			t.Errorf("Circumference(): got %q, want %q", got, tc.want)
+		}
+		if tc.want == math.MaxUint64 {
+			continue
+		}
+		back := CircumferenceToDiameter(got)
+		if back+1 < tc.diameter || back > tc.diameter+1 {
+			t.Errorf("CircumferenceToDiameter(): got %q, want %q", back, tc.diameter)
+		}
+	}
+}
+
+func TestAtScale(t *testing.T) {
+	testCases := []struct {
+		real  Length
+		ratio float64
+		model Length
+	}{
+		{
+			real:  0,
+			ratio: 1.0 / 48,
+			model: 0,
+		},
+		{
+			real:  12 * Meter,
+			ratio: 1.0 / 48,
+			model: 250 * Millimeter,
+		},
+		{
+			real:  8 * Foot,
+			ratio: 1.0 / 48,
+			model: 2 * Inch,
+		},
+		{
+			real:  8700 * Millimeter,
+			ratio: 1.0 / 87,
+			model: 100 * Millimeter,
+		},
+		{
+			real:  1 * Meter,
+			ratio: 1.0 / 87,
+			model: 11494253 * Nanometer,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.real.AtScale(tc.ratio); got != tc.model {
+			t.Errorf("AtScale(%f): got %q, want %q", tc.ratio, got, tc.model)
+		}
+		if got := tc.model.RealFromScale(tc.ratio); got+100 < tc.real || got > tc.real+100 {
+			t.Errorf("RealFromScale(%f): got %q, want %q", tc.ratio, got, tc.real)
+		}
+	}
+
+	if got := MaxLength.AtScale(2); got != MaxLength {
+		t.Errorf("AtScale(2): got %q, want %q", got, MaxLength)
+	}
+}
+
+func TestScaleByVolumeFactor(t *testing.T) {
+	testCases := []struct {
+		l            Length
+		volumeFactor float64
+		want         Length
+	}{
+		{l: 1 * Meter, volumeFactor: 1, want: 1 * Meter},
+		{l: 1 * Meter, volumeFactor: 2, want: 1259921050 * Nanometer},
+		{l: 1 * Meter, volumeFactor: 8, want: 2 * Meter},
+		{l: 30 * Centimeter, volumeFactor: 1.0 / 27, want: 10 * Centimeter},
+		{l: 1 * Meter, volumeFactor: 0, want: 0},
+		{l: 1 * Meter, volumeFactor: -8, want: 0},
+		{l: 1 * Meter, volumeFactor: math.NaN(), want: 0},
+		{l: MaxLength, volumeFactor: 8, want: MaxLength},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.ScaleByVolumeFactor(tc.volumeFactor); got != tc.want {
+			t.Errorf("ScaleByVolumeFactor(%q, %g): got %q, want %q", tc.l, tc.volumeFactor, got, tc.want)
+		}
+	}
+}
+
+func TestFromPixelsCalibrated(t *testing.T) {
+	// An ID-1 card is 85.6mm wide.
+	card := 856 * Millimeter / 10
+	testCases := []struct {
+		objectPx    float64
+		referencePx float64
+		want        Length
+	}{
+		{
+			objectPx:    0,
+			referencePx: 107,
+			want:        0,
+		},
+		{
+			objectPx:    107,
+			referencePx: 107,
+			want:        card,
+		},
+		{
+			objectPx:    2225,
+			referencePx: 107,
+			want:        1780 * Millimeter,
+		},
+		{
+			objectPx:    53.5,
+			referencePx: 107,
+			want:        card / 2,
+		},
+		{
+			objectPx:    2225,
+			referencePx: 0,
+			want:        0,
+		},
+		{
+			objectPx:    2225,
+			referencePx: -107,
+			want:        0,
+		},
+		{
+			objectPx:    1e30,
+			referencePx: 1,
+			want:        MaxLength,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := FromPixelsCalibrated(tc.objectPx, tc.referencePx, card); got != tc.want {
+			t.Errorf("FromPixelsCalibrated(%v, %v): got %q, want %q", tc.objectPx, tc.referencePx, got, tc.want)
+		}
+	}
+}
+
+func TestArcLength(t *testing.T) {
+	testCases := []struct {
+		radius  Length
+		radians float64
+		degrees float64
+		want    Length
+	}{
+		{
+			radius:  1 * Meter,
+			radians: 0,
+			degrees: 0,
+			want:    0,
+		},
+		{
+			radius:  1 * Meter,
+			radians: math.Pi / 2,
+			degrees: 90,
+			want:    1570796327 * Nanometer,
+		},
+		{
+			radius:  1 * Meter,
+			radians: 2 * math.Pi,
+			degrees: 360,
+			want:    6283185307 * Nanometer,
+		},
+		{
+			radius:  1 * Meter,
+			radians: -math.Pi / 2,
+			degrees: -90,
+			want:    1570796327 * Nanometer,
+		},
+		{
+			radius:  25 * Centimeter,
+			radians: 6 * math.Pi,
+			degrees: 1080,
+			want:    4712388980 * Nanometer,
+		},
+		{
+			radius:  MaxLength,
+			radians: 2 * math.Pi,
+			degrees: 360,
+			want:    MaxLength,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := ArcLength(tc.radius, tc.radians); got != tc.want {
+			t.Errorf("ArcLength(%q, %g): got %q, want %q", tc.radius, tc.radians, got, tc.want)
+		}
+		if got := ArcLengthDeg(tc.radius, tc.degrees); got != tc.want {
+			t.Errorf("ArcLengthDeg(%q, %g): got %q, want %q", tc.radius, tc.degrees, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package lengths
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ToImperialNice returns the length snapped to the closest multiple of
+// 1/denominator inch, such as the closest quarter inch for a denominator of
+// 4, so that it displays as a "nice" value in inches. The length is returned
+// unchanged if denominator is not positive, if the fractions are too fine
+// to be represented or if the snapped length would exceed MaxLength.
+func (l Length) ToImperialNice(denominator int) Length {
+	if denominator <= 0 {
+		return l
+	}
+	d := uint64(denominator)
+	k, ok := l.fractionsOfInch(d)
+	if !ok {
+		return l
+	}
+
+	// round(k*Inch / d) nanometers.
+	hi, lo := bits.Mul64(k, uint64(Inch))
+	lo, carry := bits.Add64(lo, d/2, 0)
+	if hi+carry >= d {
+		// The snapped length would round past MaxLength.
+		return l
+	}
+	n, _ := bits.Div64(hi+carry, lo, d)
+	return Length(n)
+}
+
+// fractionsOfInch returns round(l*d / Inch), the length as a whole number of
+// 1/d inch. ok is false if the number cannot be represented.
+func (l Length) fractionsOfInch(d uint64) (k uint64, ok bool) {
+	hi, lo := bits.Mul64(uint64(l), d)
+	lo, carry := bits.Add64(lo, uint64(Inch)/2, 0)
+	if hi+carry >= uint64(Inch) {
+		return 0, false
+	}
+	k, _ = bits.Div64(hi+carry, lo, uint64(Inch))
+	return k, true
+}
+
+// SnapImperialFraction returns the length snapped to the closest marking of a
+// standard tape measure, down to 1/16 inch, broken down in feet, inches and a
+// reduced fraction of an inch: 1/2, 1/4, 3/4, 1/8... For whole inches, num is
+// zero and den is 1. On 32-bit platforms, where int cannot count the feet of
+// lengths beyond about 654km, feet saturate at math.MaxInt.
+func (l Length) SnapImperialFraction() (feet int, inches int, num int, den int) {
+	l = l.ToImperialNice(16)
+	feet = math.MaxInt
+	if l/Foot <= math.MaxInt {
+		feet = int(l / Foot)
+	}
+	l %= Foot
+	inches, l = int(l/Inch), l%Inch
+	num, den = int(l/(Inch/16)), 16
+	if num == 0 {
+		return feet, inches, 0, 1
+	}
+	for num%2 == 0 {
+		num, den = num/2, den/2
+	}
+	return feet, inches, num, den
+}
+
+// StringYardsFeetInches returns the length broken down in yards, feet and
+// inches as used in US construction, such as "2 yd 1 ft 6 1/2 in". Inches are
+// rounded to the closest 1/denominator inch, with the fraction reduced, and
+// carried over to feet and yards when they add up to a whole foot or yard.
+// Zero components are omitted, except for a zero length which returns
+// "0 in". A denominator that is not positive, or too large to count the
+// length in, rounds to whole inches.
+func (l Length) StringYardsFeetInches(denominator int) string {
+	d := uint64(1)
+	if denominator > 0 {
+		d = uint64(denominator)
+	}
+	k, ok := l.fractionsOfInch(d)
+	if !ok {
+		d = 1
+		k, _ = l.fractionsOfInch(d)
+	}
+	whole, num := k/d, k%d
+	yards, feet, inches := whole/36, whole%36/12, whole%12
+
+	var parts []string
+	if yards > 0 {
+		parts = append(parts, strconv.FormatUint(yards, 10)+" yd")
+	}
+	if feet > 0 {
+		parts = append(parts, strconv.FormatUint(feet, 10)+" ft")
+	}
+	if inches > 0 || num > 0 || len(parts) == 0 {
+		in := strconv.FormatUint(inches, 10)
+		if num > 0 {
+			g := uint64(GCD(Length(num), Length(d)))
+			frac := strconv.FormatUint(num/g, 10) + "/" + strconv.FormatUint(d/g, 10)
+			if inches > 0 {
+				in += " " + frac
+			} else {
+				in = frac
+			}
+		}
+		parts = append(parts, in+" in")
+	}
+	return strings.Join(parts, " ")
+}
+
+// UKDistanceThreshold is the length from which StringUK phrases lengths as a
+// distance in miles and yards rather than as a body-scale length in feet and
+// inches. It defaults to 3m, above any human height, and may be overridden by
+// applications with different needs.
+var UKDistanceThreshold = 3 * Meter
+
+// yard is the length of a yard, 3 feet. A mile is 1760 yards.
+const yard = 3 * Foot
+
+// StringUK returns the length phrased as in British usage: in feet and inches
+// rounded to the closest inch, such as "5 ft 10 in", for lengths shorter than
+// UKDistanceThreshold, and in miles and yards rounded to the closest yard,
+// such as "2 miles 150 yards", otherwise. Zero components are omitted, except
+// for a zero length which returns "0 in". As done by StringYardsFeetInches,
+// numbers and units are separated by a space.
+func (l Length) StringUK() string {
+	var parts []string
+	if l < UKDistanceThreshold {
+		inches, _ := l.fractionsOfInch(1)
+		if feet := inches / 12; feet > 0 {
+			parts = append(parts, strconv.FormatUint(feet, 10)+" ft")
+		}
+		if inches%12 > 0 || len(parts) == 0 {
+			parts = append(parts, strconv.FormatUint(inches%12, 10)+" in")
+		}
+		return strings.Join(parts, " ")
+	}
+
+	yards := uint64(l / yard)
+	if l%yard >= yard-l%yard {
+		yards++
+	}
+	if miles := yards / 1760; miles > 0 {
+		parts = append(parts, plural(miles, "mile"))
+	}
+	if yards%1760 > 0 || len(parts) == 0 {
+		parts = append(parts, plural(yards%1760, "yard"))
+	}
+	return strings.Join(parts, " ")
+}
+
+// plural returns n followed by the unit name, pluralized unless n is 1.
+func plural(n uint64, name string) string {
+	if n == 1 {
+		return "1 " + name
+	}
+	return strconv.FormatUint(n, 10) + " " + name + "s"
+}
@@ -0,0 +1,273 @@
+package lengths
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToImperialNice(t *testing.T) {
+	testCases := []struct {
+		l           Length
+		denominator int
+		want        Length
+	}{
+		{
+			l:           0,
+			denominator: 4,
+			want:        0,
+		},
+		{
+			l:           178 * Centimeter,
+			denominator: 4,
+			want:        70 * Inch,
+		},
+		{
+			l:           178 * Centimeter,
+			denominator: 8,
+			want:        70*Inch + Inch/8,
+		},
+		{
+			l:           180 * Centimeter,
+			denominator: 4,
+			want:        70*Inch + 3*Inch/4,
+		},
+		{
+			l:           180 * Centimeter,
+			denominator: 8,
+			want:        70*Inch + 7*Inch/8,
+		},
+		{
+			l:           Inch/8 - Nanometer,
+			denominator: 4,
+			want:        0,
+		},
+		{
+			l:           Inch / 8,
+			denominator: 4,
+			want:        Inch / 4,
+		},
+		{
+			l:           Inch / 2,
+			denominator: 3,
+			want:        16933333 * Nanometer,
+		},
+		{
+			l:           178 * Centimeter,
+			denominator: 0,
+			want:        178 * Centimeter,
+		},
+		{
+			// The closest half inch is past MaxLength.
+			l:           MaxLength - 1,
+			denominator: 2,
+			want:        MaxLength - 1,
+		},
+		{
+			l:           MaxLength - Inch,
+			denominator: 2,
+			want:        MaxLength - Inch + 548385,
+		},
+		{
+			// Rounding carries the number of fractions past 64 bits.
+			l:           18446743347459813527,
+			denominator: 25400001,
+			want:        18446743347459813527,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.ToImperialNice(tc.denominator); got != tc.want {
+			t.Errorf("ToImperialNice(%d): got %q, want %q", tc.denominator, got, tc.want)
+		}
+	}
+}
+
+func TestSnapImperialFraction(t *testing.T) {
+	testCases := []struct {
+		l          Length
+		wantFeet   int
+		wantInches int
+		wantNum    int
+		wantDen    int
+	}{
+		{
+			l:          0,
+			wantFeet:   0,
+			wantInches: 0,
+			wantNum:    0,
+			wantDen:    1,
+		},
+		{
+			l:          5*Foot + 10*Inch,
+			wantFeet:   5,
+			wantInches: 10,
+			wantNum:    0,
+			wantDen:    1,
+		},
+		{
+			l:          3*Inch + Inch/2,
+			wantFeet:   0,
+			wantInches: 3,
+			wantNum:    1,
+			wantDen:    2,
+		},
+		{
+			l:          1*Inch + 3*Inch/4 + Inch/100,
+			wantFeet:   0,
+			wantInches: 1,
+			wantNum:    3,
+			wantDen:    4,
+		},
+		{
+			l:          15 * Inch / 16,
+			wantFeet:   0,
+			wantInches: 0,
+			wantNum:    15,
+			wantDen:    16,
+		},
+		{
+			l:          178 * Centimeter,
+			wantFeet:   5,
+			wantInches: 10,
+			wantNum:    1,
+			wantDen:    16,
+		},
+		{
+			l:          Foot - Inch/100,
+			wantFeet:   1,
+			wantInches: 0,
+			wantNum:    0,
+			wantDen:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		gotFeet, gotInches, gotNum, gotDen := tc.l.SnapImperialFraction()
+		if gotFeet != tc.wantFeet || gotInches != tc.wantInches || gotNum != tc.wantNum || gotDen != tc.wantDen {
+			t.Errorf(
+				"SnapImperialFraction(%q): got %d' %d %d/%d\", want %d' %d %d/%d\"",
+				tc.l,
+				gotFeet,
+				gotInches,
+				gotNum,
+				gotDen,
+				tc.wantFeet,
+				tc.wantInches,
+				tc.wantNum,
+				tc.wantDen,
+			)
+		}
+	}
+}
+
+func TestStringYardsFeetInches(t *testing.T) {
+	testCases := []struct {
+		l           Length
+		denominator int
+		want        string
+	}{
+		{
+			l:           0,
+			denominator: 16,
+			want:        "0 in",
+		},
+		{
+			l:           2*36*Inch + 1*Foot + 6*Inch,
+			denominator: 16,
+			want:        "2 yd 1 ft 6 in",
+		},
+		{
+			l:           2*36*Inch + 1*Foot + 6*Inch + Inch/2,
+			denominator: 16,
+			want:        "2 yd 1 ft 6 1/2 in",
+		},
+		{
+			l:           1*Foot + 3*Inch/8,
+			denominator: 16,
+			want:        "1 ft 3/8 in",
+		},
+		{
+			l:           36*Inch + 6*Inch,
+			denominator: 4,
+			want:        "1 yd 6 in",
+		},
+		{
+			// 11 31/32 inches round up to a whole foot.
+			l:           11*Inch + 31*Inch/32,
+			denominator: 16,
+			want:        "1 ft",
+		},
+		{
+			// 2 ft 11 15/16 inches round up to a whole yard.
+			l:           2*Foot + 11*Inch + 15*Inch/16,
+			denominator: 8,
+			want:        "1 yd",
+		},
+		{
+			l:           Inch/3 + 1,
+			denominator: 3,
+			want:        "1/3 in",
+		},
+		{
+			l:           5*Inch + Inch/2,
+			denominator: 0,
+			want:        "6 in",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.StringYardsFeetInches(tc.denominator); got != tc.want {
+			t.Errorf("StringYardsFeetInches(%q, %d): got %q, want %q", tc.l, tc.denominator, got, tc.want)
+		}
+	}
+}
+
+func TestStringYardsFeetInchesLargeDenominator(t *testing.T) {
+	if math.MaxInt < 1<<62 {
+		t.Skip("int cannot hold a denominator of 1<<62")
+	}
+	d := math.MaxInt/2 + 1 // 1<<62
+
+	// 36 and 12 times the denominator overflow.
+	if got, want := Inch.StringYardsFeetInches(d), "1 in"; got != want {
+		t.Errorf("StringYardsFeetInches(%q, %d): got %q, want %q", Inch, d, got, want)
+	}
+	// Too many fractions to count: rounded to whole inches.
+	l := Foot + Inch/2
+	if got, want := l.StringYardsFeetInches(d), "1 ft 1 in"; got != want {
+		t.Errorf("StringYardsFeetInches(%q, %d): got %q, want %q", l, d, got, want)
+	}
+}
+
+func TestStringUK(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want string
+	}{
+		{l: 0, want: "0 in"},
+		{l: 178 * Centimeter, want: "5 ft 10 in"},
+		{l: 6 * Foot, want: "6 ft"},
+		{l: 6*Foot - Inch/4, want: "6 ft"},
+		{l: 9 * Inch, want: "9 in"},
+		{l: 3 * Meter, want: "3 yards"},
+		{l: 1000 * Meter, want: "1094 yards"},
+		{l: 1760 * yard, want: "1 mile"},
+		{l: 5 * Kilometer, want: "3 miles 188 yards"},
+		{l: 1761 * yard, want: "1 mile 1 yard"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.StringUK(); got != tc.want {
+			t.Errorf("StringUK(%q): got %q, want %q", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestStringUKThreshold(t *testing.T) {
+	defer func(threshold Length) { UKDistanceThreshold = threshold }(UKDistanceThreshold)
+	UKDistanceThreshold = 100 * Meter
+
+	if got, want := (30 * Foot).StringUK(), "30 ft"; got != want {
+		t.Errorf("StringUK(%q): got %q, want %q", 30*Foot, got, want)
+	}
+}
@@ -0,0 +1,209 @@
+package lengths
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DecodeStream decodes a top-level JSON array of lengths from r one element
+// at a time, without loading the whole array in memory, and calls fn for
+// each length in order. Elements are either strings parsed as done by
+// ParseLengthUnit, such as "178cm", or integer numbers of nanometers.
+//
+// DecodeStream stops at the first error, including errors returned by fn,
+// which are returned as is.
+func DecodeStream(r io.Reader, fn func(Length) error) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("lengths: decoding stream: %w", err)
+	}
+	if tok != json.Delim('[') {
+		return errors.New("lengths: decoding stream: not a JSON array")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("lengths: decoding stream: %w", err)
+		}
+		var l Length
+		switch v := tok.(type) {
+		case string:
+			if l, _, err = ParseLengthUnit(v); err != nil {
+				return err
+			}
+		case json.Number:
+			n, err := strconv.ParseUint(v.String(), 10, 64)
+			if err != nil {
+				return fmt.Errorf("lengths: decoding stream: invalid nanometer count %s", v)
+			}
+			l = Length(n)
+		default:
+			return fmt.Errorf("lengths: decoding stream: unexpected %v", tok)
+		}
+		if err := fn(l); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("lengths: decoding stream: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Lengths are decoded from
+// strings as done by UnmarshalText, such as "1.78m", and from integer
+// numbers of nanometers, as encoded before lengths implemented
+// encoding.TextMarshaler. JSON null leaves the length unchanged.
+func (l *Length) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	switch {
+	case s == "null":
+		return nil
+	case strings.HasPrefix(s, `"`):
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return l.UnmarshalText([]byte(text))
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("lengths: decoding JSON: invalid nanometer count %s", s)
+	}
+	*l = Length(n)
+	return nil
+}
+
+// A JSONFormat selects how InUnit encodes lengths to JSON.
+type JSONFormat int
+
+const (
+	// JSONNumber encodes lengths as a plain number of the unit, such as 178.
+	JSONNumber JSONFormat = iota
+	// JSONString encodes lengths as a string in the unit, such as "178cm",
+	// or formatted as done by MarshalText if the unit is zero.
+	JSONString
+	// JSONObject encodes lengths as a self-describing object holding a
+	// number of the unit and its symbol, such as {"value":1.78,"unit":"m"},
+	// or in the unit String would use if the unit is zero.
+	JSONObject
+)
+
+// jsonObject is the JSONObject representation of a length.
+type jsonObject struct {
+	Value json.RawMessage `json:"value"`
+	Unit  string          `json:"unit"`
+}
+
+// InUnit wraps a length so that it is encoded to JSON in a fixed unit and
+// format, as required by APIs with a fixed contract: a length of 178cm with a
+// Unit of Millimeter is encoded as 1780, and as "1780mm" with the JSONString
+// Format. Decoding reads numbers as a number of Unit, so Unit must be set
+// before decoding numbers:
+//
+//	v := struct{ Height lengths.InUnit }{lengths.InUnit{Unit: lengths.Centimeter}}
+//	err := json.Unmarshal([]byte(`{"Height":178}`), &v)
+//
+// Strings are decoded as done by ParseLength and objects by their own unit,
+// whatever the Format and Unit.
+// Numbers are encoded with as many decimals as needed and decoded numbers are
+// rounded to the closest nanometer.
+//
+// InUnit is also encoded to XML and database columns in its Unit: see
+// MarshalXML and Value.
+type InUnit struct {
+	Length Length
+	Unit   Length
+	Format JSONFormat
+	// UnitAttr is the name of the XML attribute holding the unit symbol,
+	// "unit" if empty.
+	UnitAttr string
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v InUnit) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Format == JSONString && v.Unit == 0:
+		return []byte(strconv.Quote(v.Length.exactString())), nil
+	case v.Format == JSONObject:
+		unit := v.Unit
+		if unit == 0 {
+			unit = v.Length.autoUnit()
+		}
+		symbol, ok := unitSymbols[unit]
+		if !ok {
+			return nil, errors.New("lengths: encoding JSON: unit without symbol")
+		}
+		return json.Marshal(jsonObject{Value: json.RawMessage(v.Length.formatExact(unit)), Unit: symbol})
+	case v.Unit == 0:
+		return nil, errors.New("lengths: encoding JSON: zero unit")
+	case v.Format == JSONString:
+		if _, ok := unitSymbols[v.Unit]; !ok {
+			return nil, errors.New("lengths: encoding JSON: unit without symbol")
+		}
+		return []byte(strconv.Quote(v.Length.formatExact(v.Unit) + unitSymbols[v.Unit])), nil
+	case v.Format == JSONNumber:
+		return []byte(v.Length.formatExact(v.Unit)), nil
+	default:
+		return nil, errors.New("lengths: encoding JSON: unknown format")
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler. As is conventional, null leaves
+// the length unchanged.
+func (v *InUnit) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	switch {
+	case s == "null":
+		return nil
+	case strings.HasPrefix(s, `"`):
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.Length.UnmarshalText([]byte(text))
+	case strings.HasPrefix(s, "{"):
+		var o jsonObject
+		if err := json.Unmarshal(data, &o); err != nil {
+			return fmt.Errorf("lengths: decoding JSON: %w", err)
+		}
+		unit, ok := unitsBySymbol[o.Unit]
+		if !ok {
+			return errors.New("lengths: decoding JSON: unknown unit " + strconv.Quote(o.Unit))
+		}
+		l, ok := numberIn(string(o.Value), unit)
+		if !ok {
+			return fmt.Errorf("lengths: decoding JSON: invalid value %q", o.Value)
+		}
+		v.Length = l
+		return nil
+	case v.Unit == 0:
+		return errors.New("lengths: decoding JSON: zero unit")
+	}
+	l, ok := numberIn(s, v.Unit)
+	if !ok {
+		return fmt.Errorf("lengths: decoding JSON: invalid number %s", s)
+	}
+	v.Length = l
+	return nil
+}
+
+// numberIn returns the length of the JSON number s of unit, converted
+// exactly. ok is false if s is not a non-negative number or overflows.
+func numberIn(s string, unit Length) (Length, bool) {
+	d, rest, ok := (Parser{}).leadingDecimal(s)
+	if ok {
+		d, rest, ok = d.withExponent(rest)
+	}
+	if !ok || rest != "" {
+		return 0, false
+	}
+	return d.length(unit)
+}
@@ -0,0 +1,223 @@
+package lengths
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStream(t *testing.T) {
+	var got Lengths
+	err := DecodeStream(
+		strings.NewReader(`["178cm", 1000, "70in", "0.5 m"]`),
+		func(l Length) error {
+			got = append(got, l)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("DecodeStream(): unexpected error: %v", err)
+	}
+	want := Lengths{178 * Centimeter, 1 * Micrometer, 70 * Inch, 50 * Centimeter}
+	if len(got) != len(want) {
+		t.Fatalf("DecodeStream(): got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DecodeStream()[%d]: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecodeStreamStop(t *testing.T) {
+	errTooTall := errors.New("too tall")
+	var got Lengths
+	err := DecodeStream(
+		strings.NewReader(`["178cm", "3m", "150cm"]`),
+		func(l Length) error {
+			if l > 2*Meter {
+				return errTooTall
+			}
+			got = append(got, l)
+			return nil
+		},
+	)
+	if err != errTooTall {
+		t.Errorf("DecodeStream(): got error %v, want %v", err, errTooTall)
+	}
+	if len(got) != 1 || got[0] != 178*Centimeter {
+		t.Errorf("DecodeStream(): got %v, want [1.78m]", got)
+	}
+}
+
+func TestDecodeStreamErrors(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`{"height": "178cm"}`,
+		`["178cm", "tall"]`,
+		`["178cm", -1]`,
+		`["178cm", 1.5]`,
+		`["178cm", true]`,
+		`["178cm", ["3m"]]`,
+		`["178cm"`,
+	} {
+		err := DecodeStream(strings.NewReader(s), func(Length) error { return nil })
+		if err == nil {
+			t.Errorf("DecodeStream(%q): expected an error", s)
+		}
+	}
+}
+
+func TestInUnit(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		unit Length
+		want string
+	}{
+		{l: 178 * Centimeter, unit: Centimeter, want: `178`},
+		{l: 1785 * Millimeter, unit: Centimeter, want: `178.5`},
+		{l: 1780 * Millimeter, unit: Millimeter, want: `1780`},
+		{l: 178 * Centimeter, unit: Meter, want: `1.78`},
+		{l: 70 * Inch, unit: Inch, want: `70`},
+		{l: 0, unit: Centimeter, want: `0`},
+	}
+
+	for _, tc := range testCases {
+		b, err := json.Marshal(InUnit{Length: tc.l, Unit: tc.unit})
+		if err != nil || string(b) != tc.want {
+			t.Errorf("MarshalJSON(%q, %q): got %s, %v, want %s", tc.l, tc.unit, b, err, tc.want)
+			continue
+		}
+		got := InUnit{Unit: tc.unit}
+		if err := json.Unmarshal(b, &got); err != nil || got.Length != tc.l {
+			t.Errorf("UnmarshalJSON(%s, %q): got %q, %v, want %q", b, tc.unit, got.Length, err, tc.l)
+		}
+	}
+}
+
+func TestInUnitField(t *testing.T) {
+	v := struct {
+		Height InUnit `json:"height"`
+	}{
+		Height: InUnit{Unit: Millimeter},
+	}
+	if err := json.Unmarshal([]byte(`{"height": 1780}`), &v); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error: %v", err)
+	}
+	if v.Height.Length != 178*Centimeter {
+		t.Errorf("Unmarshal(): got %q, want %q", v.Height.Length, 178*Centimeter)
+	}
+	if err := json.Unmarshal([]byte(`{"height": 1.7805e3}`), &v); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error: %v", err)
+	}
+	if v.Height.Length != 17805*(Millimeter/10) {
+		t.Errorf("Unmarshal(): got %q, want %q", v.Height.Length, 17805*(Millimeter/10))
+	}
+	if err := json.Unmarshal([]byte(`{"height": null}`), &v); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error: %v", err)
+	}
+	if v.Height.Length != 17805*(Millimeter/10) {
+		t.Errorf("Unmarshal(null): got %q, want %q", v.Height.Length, 17805*(Millimeter/10))
+	}
+}
+
+func TestInUnitErrors(t *testing.T) {
+	if b, err := json.Marshal(InUnit{Length: 178 * Centimeter}); err == nil {
+		t.Errorf("MarshalJSON(zero unit): got %s, want error", b)
+	}
+	for _, s := range []string{`"tall"`, `-1`, `true`, `1e30`} {
+		v := InUnit{Unit: Centimeter}
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			t.Errorf("UnmarshalJSON(%s): got %q, want error", s, v.Length)
+		}
+	}
+	var v InUnit
+	if err := json.Unmarshal([]byte(`178`), &v); err == nil {
+		t.Errorf("UnmarshalJSON(zero unit): got %q, want error", v.Length)
+	}
+}
+
+func TestInUnitString(t *testing.T) {
+	testCases := []struct {
+		v    InUnit
+		want string
+	}{
+		{v: InUnit{Length: 178 * Centimeter, Unit: Centimeter, Format: JSONString}, want: `"178cm"`},
+		{v: InUnit{Length: 178 * Centimeter, Unit: Millimeter, Format: JSONString}, want: `"1780mm"`},
+		{v: InUnit{Length: 1234567891, Unit: Meter, Format: JSONString}, want: `"1.234567891m"`},
+		{v: InUnit{Length: 70 * Inch, Unit: Inch, Format: JSONString}, want: `"70in"`},
+		{v: InUnit{Length: 178 * Centimeter, Unit: 0, Format: JSONString}, want: `"1.78m"`},
+		{v: InUnit{Length: 1234567891, Unit: Meter, Format: JSONNumber}, want: `1.234567891`},
+	}
+
+	for _, tc := range testCases {
+		b, err := json.Marshal(tc.v)
+		if err != nil || string(b) != tc.want {
+			t.Errorf("MarshalJSON(%q, %q, %d): got %s, %v, want %s", tc.v.Length, tc.v.Unit, tc.v.Format, b, err, tc.want)
+			continue
+		}
+		got := InUnit{Unit: tc.v.Unit, Format: tc.v.Format}
+		if err := json.Unmarshal(b, &got); err != nil || got.Length != tc.v.Length {
+			t.Errorf("UnmarshalJSON(%s): got %q, %v, want %q", b, got.Length, err, tc.v.Length)
+		}
+	}
+
+	// Numbers and strings are both accepted whatever the format.
+	v := InUnit{Unit: Millimeter, Format: JSONString}
+	if err := json.Unmarshal([]byte(`1780`), &v); err != nil || v.Length != 178*Centimeter {
+		t.Errorf("UnmarshalJSON(1780): got %q, %v, want %q", v.Length, err, 178*Centimeter)
+	}
+	v = InUnit{Unit: Millimeter}
+	if err := json.Unmarshal([]byte(`"5'10\""`), &v); err != nil || v.Length != 5*Foot+10*Inch {
+		t.Errorf("UnmarshalJSON(5'10\"): got %q, %v, want %q", v.Length, err, 5*Foot+10*Inch)
+	}
+
+	if b, err := json.Marshal(InUnit{Length: 1, Unit: 3 * Millimeter, Format: JSONString}); err == nil {
+		t.Errorf("MarshalJSON(unit without symbol): got %s, want error", b)
+	}
+	if b, err := json.Marshal(InUnit{Length: 1, Unit: Millimeter, Format: JSONFormat(42)}); err == nil {
+		t.Errorf("MarshalJSON(unknown format): got %s, want error", b)
+	}
+}
+
+func TestInUnitObject(t *testing.T) {
+	testCases := []struct {
+		v    InUnit
+		want string
+	}{
+		{v: InUnit{Length: 175 * Centimeter, Unit: Meter, Format: JSONObject}, want: `{"value":1.75,"unit":"m"}`},
+		{v: InUnit{Length: 175 * Centimeter, Unit: Millimeter, Format: JSONObject}, want: `{"value":1750,"unit":"mm"}`},
+		{v: InUnit{Length: 42 * Centimeter, Format: JSONObject}, want: `{"value":42,"unit":"cm"}`},
+		{v: InUnit{Length: 70 * Inch, Unit: Inch, Format: JSONObject}, want: `{"value":70,"unit":"in"}`},
+		{v: InUnit{Length: 0, Format: JSONObject}, want: `{"value":0,"unit":"nm"}`},
+	}
+
+	for _, tc := range testCases {
+		b, err := json.Marshal(tc.v)
+		if err != nil || string(b) != tc.want {
+			t.Errorf("MarshalJSON(%q, %q): got %s, %v, want %s", tc.v.Length, tc.v.Unit, b, err, tc.want)
+			continue
+		}
+		var got InUnit
+		if err := json.Unmarshal(b, &got); err != nil || got.Length != tc.v.Length {
+			t.Errorf("UnmarshalJSON(%s): got %q, %v, want %q", b, got.Length, err, tc.v.Length)
+		}
+	}
+
+	var v InUnit
+	if err := json.Unmarshal([]byte(`{"unit": "ft", "value": 6}`), &v); err != nil || v.Length != 6*Foot {
+		t.Errorf("UnmarshalJSON(): got %q, %v, want %q", v.Length, err, 6*Foot)
+	}
+	for _, s := range []string{
+		`{"value": 1.75, "unit": "cubit"}`,
+		`{"value": 1.75}`,
+		`{"value": -1, "unit": "m"}`,
+		`{"value": "1.75", "unit": "m"}`,
+		`{"value": 1.75, "unit": 1}`,
+	} {
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			t.Errorf("UnmarshalJSON(%s): got %q, want error", s, v.Length)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package lengths
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A layoutToken is either literal text or a verb of a FormatLayout layout.
+type layoutToken struct {
+	text      string // literal text, or the verb as written
+	verb      byte   // 0 for literal text
+	precision int    // -1 if not specified
+	unit      Length // unit of the '{' verb
+}
+
+// parseLayout splits a FormatLayout layout into tokens.
+func parseLayout(layout string) []layoutToken {
+	var tokens []layoutToken
+	for layout != "" {
+		i := strings.IndexByte(layout, '%')
+		if i == -1 {
+			tokens = append(tokens, layoutToken{text: layout})
+			break
+		}
+		if i > 0 {
+			tokens = append(tokens, layoutToken{text: layout[:i]})
+		}
+
+		start := layout[i:]
+		layout = layout[i+1:]
+		tok := layoutToken{precision: -1}
+		if strings.HasPrefix(layout, ".") {
+			n := 1
+			for n < len(layout) && '0' <= layout[n] && layout[n] <= '9' {
+				n++
+			}
+			tok.precision, _ = strconv.Atoi(layout[1:n])
+			layout = layout[n:]
+		}
+		if layout == "" {
+			tok.text = "%!(NOVERB)"
+			tokens = append(tokens, tok)
+			break
+		}
+		tok.verb = layout[0]
+		layout = layout[1:]
+		if tok.verb == '{' {
+			symbol, rest, found := strings.Cut(layout, "}")
+			unit, ok := unitsBySymbol[symbol]
+			if !found || !ok {
+				tok.verb = '!'
+			} else {
+				tok.unit = unit
+				layout = rest
+			}
+		}
+		tok.text = start[:len(start)-len(layout)]
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// FormatLayout returns the length formatted according to layout, where verbs
+// introduced by '%' are replaced by components of the length, in the spirit
+// of time.Time.Format:
+//
+//	%v      the length as formatted by String
+//	%f      the whole feet of the length broken down in feet and inches
+//	%i      the remaining inches of the length broken down in feet and inches
+//	%{unit} the length as a number of unit, such as %{cm} for centimeters;
+//	        unit is any symbol accepted by ParseLengthUnit
+//	%%      a percent sign
+//
+// The %i and %{unit} verbs accept a precision, such as %.1i for inches with
+// one decimal; by default they use as many decimals as needed. The length is
+// rounded to the precision of %i before being broken down, so that inches
+// never round up to 12:
+//
+//	l := 178 * lengths.Centimeter
+//	fmt.Print(l.FormatLayout("%f ft %.1i in")) // prints 5 ft 10.1 in
+//	fmt.Print(l.FormatLayout("%.1{m} meters")) // prints 1.8 meters
+//
+// Invalid verbs are replaced by %! followed by the verb, as done by fmt.
+func (l Length) FormatLayout(layout string) string {
+	tokens := parseLayout(layout)
+
+	imperial := l
+	for _, tok := range tokens {
+		if tok.verb == 'i' && tok.precision >= 0 {
+			denominator := 1
+			for i := 0; i < tok.precision && i < 9; i++ {
+				denominator *= 10
+			}
+			imperial = l.ToImperialNice(denominator)
+			break
+		}
+	}
+	feet, inches := imperial/Foot, imperial%Foot
+
+	var b strings.Builder
+	for _, tok := range tokens {
+		switch tok.verb {
+		case 0:
+			b.WriteString(tok.text)
+		case 'v':
+			b.WriteString(l.String())
+		case 'f':
+			b.WriteString(strconv.FormatUint(uint64(feet), 10))
+		case 'i':
+			b.WriteString(inches.formatNumber(Inch, tok.precision))
+		case '{':
+			b.WriteString(l.formatNumber(tok.unit, tok.precision))
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteString("%!" + tok.text[1:])
+		}
+	}
+	return b.String()
+}
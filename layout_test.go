@@ -0,0 +1,73 @@
+package lengths
+
+import "testing"
+
+func TestFormatLayout(t *testing.T) {
+	testCases := []struct {
+		l      Length
+		layout string
+		want   string
+	}{
+		{
+			l:      178 * Centimeter,
+			layout: "",
+			want:   "",
+		},
+		{
+			l:      178 * Centimeter,
+			layout: "height: %v",
+			want:   "height: 1.78m",
+		},
+		{
+			l:      178 * Centimeter,
+			layout: "%f ft %.1i in",
+			want:   "5 ft 10.1 in",
+		},
+		{
+			l:      178 * Centimeter,
+			layout: "%f'%.0i\"",
+			want:   "5'10\"",
+		},
+		{
+			l:      6*Foot - Millimeter,
+			layout: "%f ft %.1i in",
+			want:   "6 ft 0.0 in",
+		},
+		{
+			l:      6*Foot + Inch/2,
+			layout: "%f ft %i in",
+			want:   "6 ft 0.5 in",
+		},
+		{
+			l:      178 * Centimeter,
+			layout: "%{m} m / %{cm} cm / %.3{in} in",
+			want:   "1.78 m / 178 cm / 70.079 in",
+		},
+		{
+			l:      1785 * Millimeter,
+			layout: "%.1{m} meters",
+			want:   "1.8 meters",
+		},
+		{
+			l:      12345 * Nanometer,
+			layout: "%{um}μm",
+			want:   "12.345μm",
+		},
+		{
+			l:      178 * Centimeter,
+			layout: "100%% of %v",
+			want:   "100% of 1.78m",
+		},
+		{
+			l:      178 * Centimeter,
+			layout: "%x %{furlong} %",
+			want:   "%!x %!{furlong} %!(NOVERB)",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.FormatLayout(tc.layout); got != tc.want {
+			t.Errorf("FormatLayout(%q): got %q, want %q", tc.layout, got, tc.want)
+		}
+	}
+}
@@ -2,10 +2,7 @@
 
 package lengths
 
-import (
-	"fmt"
-	"strconv"
-)
+import "math"
 
 // A Length represents the extent of something from end to end as an uint64
 // nanometer count (as a length cannot be negative). The representation limits
@@ -13,6 +10,9 @@ import (
 // more than 40 times the distance between Earth and the Moon).
 type Length uint64
 
+// MaxLength is the longest representable length.
+const MaxLength Length = math.MaxUint64
+
 // Common length units.
 //
 // To count the number of units in a Length, divide it by the unit:
@@ -32,12 +32,20 @@ const (
 	Micrometer        = 1e3 * Nanometer
 	Millimeter        = 1e6 * Nanometer
 	Centimeter        = 1e7 * Nanometer
+	Decimeter         = 1e8 * Nanometer
 	Meter             = 1e9 * Nanometer
 	Kilometer         = 1e12 * Nanometer
 	Inch              = 254e5 * Nanometer
 	Foot              = 3048e5 * Nanometer
 )
 
+// ToNanometers returns the length as its underlying nanometer count. It is
+// equivalent to uint64(l) and to Nano but makes the unit explicit at call
+// sites.
+func (l Length) ToNanometers() uint64 {
+	return uint64(l)
+}
+
 // Micrometers returns the length as a floating point number of micrometers.
 func (l Length) Micrometers() float64 {
 	return float64(l/Micrometer) + float64(l%Micrometer)/1e3
@@ -48,6 +56,16 @@ func (l Length) Millimeters() float64 {
 	return float64(l/Millimeter) + float64(l%Millimeter)/1e6
 }
 
+// RoundedMillimeters returns the length as an integer number of millimeters,
+// rounded half to even, as taken by legacy APIs.
+func (l Length) RoundedMillimeters() int64 {
+	q, r := l/Millimeter, l%Millimeter
+	if r > Millimeter-r || r == Millimeter-r && q%2 == 1 {
+		q++
+	}
+	return int64(q)
+}
+
 // Centimeters returns the length as a floating point number of centimeters.
 func (l Length) Centimeters() float64 {
 	return float64(l/Centimeter) + float64(l%Centimeter)/1e7
@@ -58,12 +76,63 @@ func (l Length) Meters() float64 {
 	return float64(l/Meter) + float64(l%Meter)/1e9
 }
 
+// RoundMeters returns the length rounded to the closest multiple of
+// 10^-decimals meter, half up, such as the closest millimeter for 3 decimals,
+// as used when storing meters with a fixed precision. Negative decimals are
+// taken as zero and decimals beyond 9, finer than a nanometer, return the
+// length unchanged. A length that would round up past MaxLength is rounded
+// down instead.
+func (l Length) RoundMeters(decimals int) Length {
+	if decimals < 0 {
+		decimals = 0
+	}
+	step := Meter
+	for ; decimals > 0 && step > 1; decimals-- {
+		step /= 10
+	}
+	down := l - l%step
+	if l%step < step-l%step || down > MaxLength-step {
+		return down
+	}
+	return down + step
+}
+
+// SnapIfClose returns the closest whole multiple of unit if it is within
+// tolerance of the length, and the length unchanged otherwise. It cleans up
+// lengths such as 99.9998cm left by floating point round trips so that they
+// display as 100cm. The length is returned unchanged if unit is zero.
+func (l Length) SnapIfClose(unit, tolerance Length) Length {
+	if unit == 0 {
+		return l
+	}
+	r := l % unit
+	up := unit-r <= tolerance && l <= MaxLength-(unit-r)
+	switch {
+	case r <= tolerance && (r <= unit-r || !up):
+		return l - r
+	case up:
+		return l + (unit - r)
+	default:
+		return l
+	}
+}
+
+// Float32Meters returns the length as a float32 number of meters, as taken
+// by graphics APIs. A float32 only has about 7 significant digits, which is
+// enough for rendering but lossy: lengths are precise to about a micrometer up
+// to 10 meters, and to about a millimeter up to 10 kilometers.
+func (l Length) Float32Meters() float32 {
+	return float32(l.Meters())
+}
+
 // Kilometers returns the length as a floating point number of kilometers.
 func (l Length) Kilometers() float64 {
 	return float64(l/Kilometer) + float64(l%Kilometer)/1e12
 }
 
-// Inches returns the length as a floating point number of inches.
+// Inches returns the length as a floating point number of inches. The whole
+// number of inches of any length is below 2^53 and is thus represented
+// exactly, so the precision is preserved up to MaxLength.
 func (l Length) Inches() float64 {
 	return float64(l/Inch) + float64(l%Inch)/254e5
 }
@@ -78,6 +147,55 @@ func (l Length) FeetAndInches() (float64, float64) {
 	return float64(feet), inches.Inches()
 }
 
+// Stories returns the length as a floating point number of stories (floors)
+// of a building, each storyHeight long. Story heights vary, commonly around 3
+// meters. It returns zero if storyHeight is zero.
+func (l Length) Stories(storyHeight Length) float64 {
+	if storyHeight == 0 {
+		return 0
+	}
+	return l.in(storyHeight)
+}
+
+// Clamp returns the length limited to the [lo, hi] interval: lo if the length
+// is shorter than lo, hi if it is longer than hi and the length itself
+// otherwise.
+func (l Length) Clamp(lo, hi Length) Length {
+	switch {
+	case l < lo:
+		return lo
+	case l > hi:
+		return hi
+	default:
+		return l
+	}
+}
+
+// FloorResolution returns zero if the length is shorter than min, such as the
+// resolution of the sensor that measured it, and the length itself otherwise.
+// Unlike Clamp, it discards values below min as noise instead of raising them
+// to min.
+func (l Length) FloorResolution(min Length) Length {
+	if l < min {
+		return 0
+	}
+	return l
+}
+
+// diff returns a-b as a signed nanometer count, limited to the int64 range.
+func diff(a, b Length) int64 {
+	if a >= b {
+		if d := a - b; d <= math.MaxInt64 {
+			return int64(d)
+		}
+		return math.MaxInt64
+	}
+	if d := b - a; d <= math.MaxInt64+1 {
+		return -int64(d-1) - 1
+	}
+	return math.MinInt64
+}
+
 // Millimeters returns a length from a floating point number of millimeters.
 // The length's precision is floored to the closest nanometer.
 func Millimeters(f float64) Length {
@@ -114,25 +232,84 @@ func Feet(f float64) Length {
 	return Length(f * float64(Foot))
 }
 
-func formatFloat(f float64) string {
-	return strconv.FormatFloat(f, 'f', -1, 64)
+// roundFloat returns a length from a floating point number of nanometers,
+// rounded to the closest nanometer. ok is false if f is negative, NaN or too
+// large to be represented.
+func roundFloat(f float64) (l Length, ok bool) {
+	f = math.Round(f)
+	if !(f >= 0 && f < 1<<64) {
+		return 0, false
+	}
+	return Length(f), true
+}
+
+// saturate is like roundFloat but returns a zero length for negative or NaN
+// numbers and the longest representable length for numbers too large.
+func saturate(f float64) Length {
+	if l, ok := roundFloat(f); ok {
+		return l
+	}
+	if f > 0 {
+		return MaxLength
+	}
+	return 0
+}
+
+// fromInt returns n units, zero if n is negative and MaxLength if n units
+// are too long to be represented.
+func fromInt(n int, unit Length) Length {
+	if n < 0 {
+		return 0
+	}
+	if uint64(n) > uint64(MaxLength/unit) {
+		return MaxLength
+	}
+	return Length(n) * unit
+}
+
+// MillimetersInt returns a length from an integer number of millimeters.
+// Unlike Millimeters, the length is exact as no floating point conversion is
+// involved. Negative numbers return a zero length and numbers too large to be
+// represented return MaxLength.
+func MillimetersInt(mm int) Length {
+	return fromInt(mm, Millimeter)
+}
+
+// CentimetersInt returns a length from an integer number of centimeters, as
+// commonly found in health records. Unlike Centimeters, the length is exact as
+// no floating point conversion is involved. Negative numbers return a zero
+// length and numbers too large to be represented return MaxLength.
+func CentimetersInt(cm int) Length {
+	return fromInt(cm, Centimeter)
+}
+
+// DecimetersInt returns a length from an integer number of decimeters. The
+// length is exact as no floating point conversion is involved. Negative
+// numbers return a zero length and numbers too large to be represented return
+// MaxLength.
+func DecimetersInt(dm int) Length {
+	return fromInt(dm, Decimeter)
+}
+
+// MetersInt returns a length from an integer number of meters. Unlike Meters,
+// the length is exact as no floating point conversion is involved. Negative
+// numbers return a zero length and numbers too large to be represented return
+// MaxLength.
+func MetersInt(m int) Length {
+	return fromInt(m, Meter)
+}
+
+// InchesInt returns a length from an integer number of inches. Unlike Inches,
+// the length is exact as no floating point conversion is involved. Negative
+// numbers return a zero length and numbers too large to be represented return
+// MaxLength.
+func InchesInt(in int) Length {
+	return fromInt(in, Inch)
 }
 
 func (l Length) String() string {
-	switch {
-	case l < Nanometer:
+	if l < Nanometer {
 		return "0"
-	case l < Micrometer:
-		return fmt.Sprintf("%dnm", l/Nanometer)
-	case l < Millimeter:
-		return fmt.Sprintf("%sμm", formatFloat(l.Micrometers()))
-	case l < Centimeter:
-		return fmt.Sprintf("%smm", formatFloat(l.Millimeters()))
-	case l < Meter:
-		return fmt.Sprintf("%scm", formatFloat(l.Centimeters()))
-	case l < Kilometer:
-		return fmt.Sprintf("%sm", formatFloat(l.Meters()))
-	default:
-		return fmt.Sprintf("%skm", formatFloat(l.Kilometers()))
 	}
+	return l.FormatUnit(l.autoUnit())
 }
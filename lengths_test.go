@@ -2,6 +2,7 @@ package lengths
 
 import (
 	"math"
+	"math/big"
 	"testing"
 )
 
@@ -272,6 +273,113 @@ func TestFromFeet(t *testing.T) {
 	}
 }
 
+func TestFromInts(t *testing.T) {
+	testCases := []struct {
+		got  Length
+		want Length
+	}{
+		{
+			got:  MillimetersInt(1780),
+			want: 1780 * Millimeter,
+		},
+		{
+			got:  CentimetersInt(178),
+			want: 178 * Centimeter,
+		},
+		{
+			got:  CentimetersInt(178),
+			want: 1780000000 * Nanometer,
+		},
+		{
+			got:  DecimetersInt(18),
+			want: 180 * Centimeter,
+		},
+		{
+			got:  MetersInt(2),
+			want: 2 * Meter,
+		},
+		{
+			got:  InchesInt(70),
+			want: 70 * Inch,
+		},
+		{
+			got:  CentimetersInt(-1),
+			want: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+}
+
+func TestFromIntsOverflow(t *testing.T) {
+	if math.MaxInt < math.MaxInt64 {
+		t.Skip("int cannot overflow lengths")
+	}
+	maxMeters := int64(MaxLength / Meter)
+	testCases := []struct {
+		got  Length
+		want Length
+	}{
+		{
+			got:  MetersInt(int(maxMeters)),
+			want: Length(maxMeters) * Meter,
+		},
+		{
+			got:  MetersInt(int(maxMeters + 1)),
+			want: MaxLength,
+		},
+		{
+			got:  MetersInt(int(2 * maxMeters)),
+			want: MaxLength,
+		},
+		{
+			got:  CentimetersInt(math.MaxInt),
+			want: MaxLength,
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+}
+
+// ratFloat returns n/d as the closest floating point number.
+func ratFloat(n Length, d Length) float64 {
+	f, _ := new(big.Rat).SetFrac(
+		new(big.Int).SetUint64(uint64(n)),
+		new(big.Int).SetUint64(uint64(d)),
+	).Float64()
+	return f
+}
+
+func TestImperialPrecision(t *testing.T) {
+	for _, l := range []Length{
+		MaxLength,
+		MaxLength - 1,
+		MaxLength - Inch/2,
+		MaxLength / 3,
+		1<<53*Nanometer + 1,
+		7654321*Kilometer + 1,
+	} {
+		if got, want := l.Inches(), ratFloat(l, Inch); got != want {
+			t.Errorf("Inches(%d): got %v, want %v", l, got, want)
+		}
+		feet, inches := l.FeetAndInches()
+		if want := float64(l / Foot); feet != want {
+			t.Errorf("FeetAndInches(%d): got %v feet, want %v", l, feet, want)
+		}
+		if want := ratFloat(l%Foot, Inch); inches != want {
+			t.Errorf("FeetAndInches(%d): got %v inches, want %v", l, inches, want)
+		}
+	}
+}
+
 func TestFeetAndInches(t *testing.T) {
 	testCases := []struct {
 		l          Length
@@ -310,6 +418,243 @@ func TestFeetAndInches(t *testing.T) {
 	}
 }
 
+func TestToNanometers(t *testing.T) {
+	for _, l := range []Length{0, 1 * Nanometer, 178 * Centimeter, MaxLength} {
+		if got := l.ToNanometers(); got != uint64(l) || got != l.Nano() {
+			t.Errorf("ToNanometers(%q): got %d, want %d", l, got, uint64(l))
+		}
+	}
+}
+
+func TestRoundMeters(t *testing.T) {
+	testCases := []struct {
+		l        Length
+		decimals int
+		want     Length
+	}{
+		{l: 1784 * Millimeter, decimals: 0, want: 2 * Meter},
+		{l: 1499999999 * Nanometer, decimals: 0, want: 1 * Meter},
+		{l: 1500 * Millimeter, decimals: 0, want: 2 * Meter},
+		{l: 1784 * Millimeter, decimals: 2, want: 178 * Centimeter},
+		{l: 1785 * Millimeter, decimals: 2, want: 179 * Centimeter},
+		{l: 1784567 * Micrometer, decimals: 3, want: 1785 * Millimeter},
+		{l: 1784499 * Micrometer, decimals: 3, want: 1784 * Millimeter},
+		{l: 1784 * Millimeter, decimals: -2, want: 2 * Meter},
+		{l: 1784567891, decimals: 9, want: 1784567891},
+		{l: 1784567891, decimals: 12, want: 1784567891},
+		{l: MaxLength, decimals: 0, want: MaxLength - MaxLength%Meter},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.RoundMeters(tc.decimals); got != tc.want {
+			t.Errorf("RoundMeters(%q, %d): got %q, want %q", tc.l, tc.decimals, got, tc.want)
+		}
+	}
+}
+
+func TestSnapIfClose(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		unit      Length
+		tolerance Length
+		want      Length
+	}{
+		{l: 999998 * Micrometer, unit: Centimeter, tolerance: 10 * Micrometer, want: 100 * Centimeter},
+		{l: 1000002 * Micrometer, unit: Centimeter, tolerance: 10 * Micrometer, want: 100 * Centimeter},
+		{l: 9995 * Millimeter / 10, unit: Centimeter, tolerance: 10 * Micrometer, want: 9995 * Millimeter / 10},
+		{l: 1784 * Millimeter, unit: Centimeter, tolerance: Centimeter, want: 178 * Centimeter},
+		{l: 1786 * Millimeter, unit: Centimeter, tolerance: Centimeter, want: 179 * Centimeter},
+		{l: 178 * Centimeter, unit: Centimeter, tolerance: 0, want: 178 * Centimeter},
+		{l: 1784 * Millimeter, unit: Centimeter, tolerance: 0, want: 1784 * Millimeter},
+		{l: 1784 * Millimeter, unit: 0, tolerance: Centimeter, want: 1784 * Millimeter},
+		{l: MaxLength, unit: Meter, tolerance: Meter, want: MaxLength - MaxLength%Meter},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.SnapIfClose(tc.unit, tc.tolerance); got != tc.want {
+			t.Errorf("SnapIfClose(%q, %q, %q): got %q, want %q", tc.l, tc.unit, tc.tolerance, got, tc.want)
+		}
+	}
+}
+
+func TestRoundedMillimeters(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want int64
+	}{
+		{
+			l:    0,
+			want: 0,
+		},
+		{
+			l:    1234567 * Nanometer,
+			want: 1,
+		},
+		{
+			l:    1734567 * Nanometer,
+			want: 2,
+		},
+		{
+			l:    1500 * Micrometer,
+			want: 2,
+		},
+		{
+			l:    2500 * Micrometer,
+			want: 2,
+		},
+		{
+			l:    178 * Centimeter,
+			want: 1780,
+		},
+		{
+			l:    MaxLength,
+			want: 18446744073710,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.RoundedMillimeters(); got != tc.want {
+			t.Errorf("RoundedMillimeters(%q): got %d, want %d", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestFloat32Meters(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want float32
+	}{
+		{
+			l:    0,
+			want: 0,
+		},
+		{
+			l:    178 * Centimeter,
+			want: 1.78,
+		},
+		{
+			l:    1234567 * Micrometer,
+			want: 1.234567,
+		},
+		{
+			// Beyond 7 significant digits the precision is lost.
+			l:    1234567891 * Micrometer,
+			want: 1234.5679,
+		},
+		{
+			l:    7654321 * Kilometer,
+			want: 7654321152,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.Float32Meters(); got != tc.want {
+			t.Errorf("Float32Meters(%q): got %v, want %v", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestStories(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want float64
+	}{
+		{
+			l:    0,
+			want: 0,
+		},
+		{
+			l:    3 * Meter,
+			want: 1,
+		},
+		{
+			l:    15 * Meter,
+			want: 5,
+		},
+		{
+			l:    16500 * Millimeter,
+			want: 5.5,
+		},
+		{
+			l:    828 * Meter,
+			want: 276,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.Stories(3 * Meter); !floatEqual(got, tc.want) {
+			t.Errorf("Stories(): got %f, want %f", got, tc.want)
+		}
+	}
+	if got := (15 * Meter).Stories(0); got != 0 {
+		t.Errorf("Stories(0): got %f, want 0", got)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want Length
+	}{
+		{
+			l:    10 * Centimeter,
+			want: 50 * Centimeter,
+		},
+		{
+			l:    50 * Centimeter,
+			want: 50 * Centimeter,
+		},
+		{
+			l:    178 * Centimeter,
+			want: 178 * Centimeter,
+		},
+		{
+			l:    2 * Meter,
+			want: 2 * Meter,
+		},
+		{
+			l:    3 * Meter,
+			want: 2 * Meter,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.Clamp(50*Centimeter, 2*Meter); got != tc.want {
+			t.Errorf("Clamp(): got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestFloorResolution(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want Length
+	}{
+		{
+			l:    0,
+			want: 0,
+		},
+		{
+			l:    400 * Micrometer,
+			want: 0,
+		},
+		{
+			l:    500 * Micrometer,
+			want: 500 * Micrometer,
+		},
+		{
+			l:    178 * Centimeter,
+			want: 178 * Centimeter,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.FloorResolution(500 * Micrometer); got != tc.want {
+			t.Errorf("FloorResolution(%q): got %q, want %q", tc.l, got, tc.want)
+		}
+	}
+}
+
 func TestString(t *testing.T) {
 	testCases := []struct {
 		l    Length
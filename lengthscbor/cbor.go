@@ -0,0 +1,35 @@
+// Package lengthscbor provides CBOR encoding of lengths, for compact
+// telemetry. It lives in its own module to keep the CBOR dependency out of
+// the module graph of users of the lengths package.
+//
+// A plain lengths.Length implements encoding.BinaryMarshaler, which the CBOR
+// encoder prefers, so it encodes as a byte string holding its versioned
+// binary layout, as 46 01 80 ca e2 d0 06 for 178cm. Convert lengths to Length
+// to encode them as unsigned integer counts of nanometers instead, as
+// 1a 6a 18 a5 00 for 178cm.
+package lengthscbor
+
+import (
+	"github.com/bodygram/lengths"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// A Length is a lengths.Length encoded in CBOR as an unsigned integer count
+// of nanometers.
+type Length lengths.Length
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (l Length) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(uint64(l))
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. Negative integers
+// are rejected as lengths cannot be negative.
+func (l *Length) UnmarshalCBOR(data []byte) error {
+	var n uint64
+	if err := cbor.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*l = Length(n)
+	return nil
+}
@@ -0,0 +1,103 @@
+package lengthscbor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bodygram/lengths"
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestRoundTrip(t *testing.T) {
+	testCases := []struct {
+		l    lengths.Length
+		want []byte
+	}{
+		{
+			l:    0,
+			want: []byte{0x00},
+		},
+		{
+			l:    23 * lengths.Nanometer,
+			want: []byte{0x17},
+		},
+		{
+			l:    178 * lengths.Centimeter,
+			want: []byte{0x1a, 0x6a, 0x18, 0xa5, 0x00},
+		},
+		{
+			l:    lengths.MaxLength,
+			want: []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+	}
+
+	for _, tc := range testCases {
+		b, err := cbor.Marshal(Length(tc.l))
+		if err != nil {
+			t.Errorf("Marshal(%q): unexpected error: %v", tc.l, err)
+			continue
+		}
+		if !bytes.Equal(b, tc.want) {
+			t.Errorf("Marshal(%q): got %x, want %x", tc.l, b, tc.want)
+		}
+
+		var got Length
+		if err := cbor.Unmarshal(b, &got); err != nil {
+			t.Errorf("Unmarshal(%x): unexpected error: %v", b, err)
+			continue
+		}
+		if lengths.Length(got) != tc.l {
+			t.Errorf("Unmarshal(%x): got %q, want %q", b, lengths.Length(got), tc.l)
+		}
+	}
+}
+
+func TestStruct(t *testing.T) {
+	type telemetry struct {
+		Height Length `cbor:"h"`
+	}
+
+	b, err := cbor.Marshal(telemetry{Height: Length(178 * lengths.Centimeter)})
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error: %v", err)
+	}
+	var got telemetry
+	if err := cbor.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error: %v", err)
+	}
+	if got.Height != Length(178*lengths.Centimeter) {
+		t.Errorf("Unmarshal(): got %q, want 1.78m", lengths.Length(got.Height))
+	}
+}
+
+func TestPlainLength(t *testing.T) {
+	type telemetry struct {
+		Height lengths.Length `cbor:"h"`
+	}
+
+	// A plain lengths.Length encodes as the byte string of MarshalBinary.
+	b, err := cbor.Marshal(telemetry{Height: 178 * lengths.Centimeter})
+	want := []byte{0xa1, 0x61, 0x68, 0x46, 0x01, 0x80, 0xca, 0xe2, 0xd0, 0x06}
+	if err != nil || !bytes.Equal(b, want) {
+		t.Fatalf("Marshal(): got %x, %v, want %x", b, err, want)
+	}
+	var got telemetry
+	if err := cbor.Unmarshal(b, &got); err != nil || got.Height != 178*lengths.Centimeter {
+		t.Errorf("Unmarshal(%x): got %q, %v, want 1.78m", b, got.Height, err)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, b := range [][]byte{
+		{0x20},                   // -1
+		{0x38, 0x63},             // -100
+		{0x63, 0x31, 0x37, 0x38}, // "178"
+		{0xf9, 0x3c, 0x00},       // 1.0
+		{},
+	} {
+		var l Length
+		if err := cbor.Unmarshal(b, &l); err == nil {
+			t.Errorf("Unmarshal(%x): expected an error", b)
+		}
+	}
+}
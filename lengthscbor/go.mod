@@ -0,0 +1,10 @@
+module github.com/bodygram/lengths/lengthscbor
+
+go 1.19
+
+require (
+	github.com/bodygram/lengths v0.0.0-20261017013722-1813308ba546
+	github.com/fxamacker/cbor/v2 v2.7.0
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
@@ -0,0 +1,46 @@
+// Package lengthsjson provides a JSON encoding of lengths for public APIs,
+// where each length is an object carrying both its exact value and derived
+// fields for display:
+//
+//	{"nanometers":1780000000,"display":"1.78m","inches":70.07874015748031}
+package lengthsjson
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/bodygram/lengths"
+)
+
+// object is the JSON representation of a length.
+type object struct {
+	Nanometers *uint64 `json:"nanometers"`
+	Display    string  `json:"display"`
+	Inches     float64 `json:"inches"`
+}
+
+// Marshal returns the JSON encoding of l as an object holding its nanometer
+// count, its display string as returned by String and its number of inches.
+func Marshal(l lengths.Length) ([]byte, error) {
+	n := uint64(l)
+	return json.Marshal(object{
+		Nanometers: &n,
+		Display:    l.String(),
+		Inches:     l.Inches(),
+	})
+}
+
+// Unmarshal parses a JSON object encoded by Marshal and stores the length in
+// the value pointed to by l. The nanometers field is authoritative: the
+// derived fields are ignored.
+func Unmarshal(data []byte, l *lengths.Length) error {
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+	if o.Nanometers == nil {
+		return errors.New("lengthsjson: missing nanometers field")
+	}
+	*l = lengths.Length(*o.Nanometers)
+	return nil
+}
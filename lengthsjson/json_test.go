@@ -0,0 +1,73 @@
+package lengthsjson
+
+import (
+	"testing"
+
+	"github.com/bodygram/lengths"
+)
+
+func TestRoundTrip(t *testing.T) {
+	testCases := []struct {
+		l    lengths.Length
+		want string
+	}{
+		{
+			l:    0,
+			want: `{"nanometers":0,"display":"0","inches":0}`,
+		},
+		{
+			l:    178 * lengths.Centimeter,
+			want: `{"nanometers":1780000000,"display":"1.78m","inches":70.07874015748031}`,
+		},
+		{
+			l:    6 * lengths.Foot,
+			want: `{"nanometers":1828800000,"display":"1.8288m","inches":72}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		b, err := Marshal(tc.l)
+		if err != nil {
+			t.Errorf("Marshal(%q): unexpected error: %v", tc.l, err)
+			continue
+		}
+		if string(b) != tc.want {
+			t.Errorf("Marshal(%q): got %s, want %s", tc.l, b, tc.want)
+		}
+
+		var got lengths.Length
+		if err := Unmarshal(b, &got); err != nil {
+			t.Errorf("Unmarshal(%s): unexpected error: %v", b, err)
+			continue
+		}
+		if got != tc.l {
+			t.Errorf("Unmarshal(%s): got %q, want %q", b, got, tc.l)
+		}
+	}
+}
+
+func TestUnmarshalAuthoritative(t *testing.T) {
+	var got lengths.Length
+	err := Unmarshal([]byte(`{"nanometers":1780000000,"display":"2m","inches":1}`), &got)
+	if err != nil {
+		t.Fatalf("Unmarshal(): unexpected error: %v", err)
+	}
+	if want := 178 * lengths.Centimeter; got != want {
+		t.Errorf("Unmarshal(): got %q, want %q", got, want)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`"178cm"`,
+		`{"display":"1.78m"}`,
+		`{"nanometers":-1}`,
+		`{"nanometers":1.5}`,
+	} {
+		var l lengths.Length
+		if err := Unmarshal([]byte(s), &l); err == nil {
+			t.Errorf("Unmarshal(%s): expected an error", s)
+		}
+	}
+}
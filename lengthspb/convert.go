@@ -0,0 +1,51 @@
+// Package lengthspb provides a Protocol Buffers message for lengths, for gRPC
+// services exchanging measurements, and converters from and to
+// lengths.Length. It lives in its own module to keep the protobuf dependency
+// out of the module graph of users of the lengths package.
+//
+// The message is defined in proto/bodygram/lengths/v1/length.proto, as
+// bodygram.lengths.v1.Length, and generated with buf.
+package lengthspb
+
+//go:generate buf generate
+
+import (
+	"errors"
+	"math"
+
+	"github.com/bodygram/lengths"
+)
+
+// ToProto returns the message for l with unit as display hint, or no hint if
+// unit is zero. It returns an error if unit has no symbol, as it is not one of
+// the common length units such as lengths.Centimeter, or if l is longer than
+// math.MaxInt64 nanometers, about 9 gigameters, which does not fit the
+// nanometers field.
+func ToProto(l lengths.Length, unit lengths.Length) (*Length, error) {
+	if l > math.MaxInt64 {
+		return nil, errors.New("lengthspb: length overflows int64")
+	}
+	symbol, ok := lengths.UnitSymbol(unit)
+	if !ok && unit != 0 {
+		return nil, errors.New("lengthspb: unit without symbol")
+	}
+	return &Length{Nanometers: int64(l), Unit: symbol}, nil
+}
+
+// FromProto returns the length of m and its unit hint, or zero if it has
+// none. It returns an error if m is nil, if its nanometer count is negative
+// or if its unit is unknown.
+func FromProto(m *Length) (l lengths.Length, unit lengths.Length, err error) {
+	if m == nil {
+		return 0, 0, errors.New("lengthspb: nil message")
+	}
+	if m.Nanometers < 0 {
+		return 0, 0, errors.New("lengthspb: negative nanometer count")
+	}
+	if m.Unit != "" {
+		if unit, err = lengths.ParseUnit(m.Unit); err != nil {
+			return 0, 0, err
+		}
+	}
+	return lengths.Length(m.Nanometers), unit, nil
+}
@@ -0,0 +1,85 @@
+package lengthspb
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bodygram/lengths"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	testCases := []struct {
+		l, unit  lengths.Length
+		wantUnit string
+	}{
+		{l: 0, unit: 0, wantUnit: ""},
+		{l: 178 * lengths.Centimeter, unit: lengths.Centimeter, wantUnit: "cm"},
+		{l: 70 * lengths.Inch, unit: lengths.Inch, wantUnit: "in"},
+		{l: 3 * lengths.Micrometer, unit: lengths.Micrometer, wantUnit: "μm"},
+		{l: math.MaxInt64, unit: 0, wantUnit: ""},
+	}
+
+	for _, tc := range testCases {
+		m, err := ToProto(tc.l, tc.unit)
+		if err != nil || m.Nanometers != int64(tc.l) || m.Unit != tc.wantUnit {
+			t.Errorf("ToProto(%q, %q): got %v, %v, want %d nanometers in %q", tc.l, tc.unit, m, err, int64(tc.l), tc.wantUnit)
+			continue
+		}
+
+		b, err := proto.Marshal(m)
+		if err != nil {
+			t.Errorf("Marshal(%v): %v", m, err)
+			continue
+		}
+		var decoded Length
+		if err := proto.Unmarshal(b, &decoded); err != nil {
+			t.Errorf("Unmarshal(%x): %v", b, err)
+			continue
+		}
+
+		wantUnit := tc.unit
+		if tc.wantUnit == "" {
+			wantUnit = 0
+		}
+		l, unit, err := FromProto(&decoded)
+		if err != nil || l != tc.l || unit != wantUnit {
+			t.Errorf("FromProto(%v): got %q, %q, %v, want %q, %q", &decoded, l, unit, err, tc.l, wantUnit)
+		}
+	}
+}
+
+func TestToProtoErrors(t *testing.T) {
+	testCases := []struct {
+		l, unit lengths.Length
+	}{
+		{l: lengths.MaxLength, unit: 0},
+		{l: lengths.Meter, unit: 3 * lengths.Meter},
+		{l: lengths.Meter, unit: 2 * lengths.Inch},
+	}
+	for _, tc := range testCases {
+		if m, err := ToProto(tc.l, tc.unit); err == nil {
+			t.Errorf("ToProto(%q, %q): got %v, want error", tc.l, tc.unit, m)
+		}
+	}
+}
+
+func TestFromProtoErrors(t *testing.T) {
+	for _, m := range []*Length{
+		nil,
+		{Nanometers: -1},
+		{Nanometers: 1, Unit: "cubit"},
+		{Nanometers: 1, Unit: "m75"},
+	} {
+		if l, _, err := FromProto(m); err == nil {
+			t.Errorf("FromProto(%v): got %q, want error", m, l)
+		}
+	}
+}
+
+func TestRegisteredPath(t *testing.T) {
+	// A namespaced path avoids conflicts in the global protobuf registry.
+	if got, want := File_bodygram_lengths_v1_length_proto.Path(), "bodygram/lengths/v1/length.proto"; got != want {
+		t.Errorf("Path(): got %q, want %q", got, want)
+	}
+}
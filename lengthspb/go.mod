@@ -0,0 +1,8 @@
+module github.com/bodygram/lengths/lengthspb
+
+go 1.19
+
+require (
+	github.com/bodygram/lengths v0.0.0-20261017013722-1813308ba546
+	google.golang.org/protobuf v1.33.0
+)
@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: bodygram/lengths/v1/length.proto
+
+package lengthspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A Length is the extent of something from end to end, such as a body
+// measurement, as an exact count of nanometers.
+type Length struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The length in nanometers. It is never negative.
+	Nanometers int64 `protobuf:"varint,1,opt,name=nanometers,proto3" json:"nanometers,omitempty"`
+	// The symbol of the unit the length is preferably displayed in, such as
+	// "cm" or "in", or empty if there is no preference.
+	Unit string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (x *Length) Reset() {
+	*x = Length{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bodygram_lengths_v1_length_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Length) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Length) ProtoMessage() {}
+
+func (x *Length) ProtoReflect() protoreflect.Message {
+	mi := &file_bodygram_lengths_v1_length_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Length.ProtoReflect.Descriptor instead.
+func (*Length) Descriptor() ([]byte, []int) {
+	return file_bodygram_lengths_v1_length_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Length) GetNanometers() int64 {
+	if x != nil {
+		return x.Nanometers
+	}
+	return 0
+}
+
+func (x *Length) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+var File_bodygram_lengths_v1_length_proto protoreflect.FileDescriptor
+
+var file_bodygram_lengths_v1_length_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x62, 0x6f, 0x64, 0x79, 0x67, 0x72, 0x61, 0x6d, 0x2f, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x13, 0x62, 0x6f, 0x64, 0x79, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x3c, 0x0a, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x6e, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6e, 0x61, 0x6e, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x6e, 0x69, 0x74, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6f, 0x64, 0x79, 0x67, 0x72, 0x61, 0x6d, 0x2f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x73, 0x2f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x73, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bodygram_lengths_v1_length_proto_rawDescOnce sync.Once
+	file_bodygram_lengths_v1_length_proto_rawDescData = file_bodygram_lengths_v1_length_proto_rawDesc
+)
+
+func file_bodygram_lengths_v1_length_proto_rawDescGZIP() []byte {
+	file_bodygram_lengths_v1_length_proto_rawDescOnce.Do(func() {
+		file_bodygram_lengths_v1_length_proto_rawDescData = protoimpl.X.CompressGZIP(file_bodygram_lengths_v1_length_proto_rawDescData)
+	})
+	return file_bodygram_lengths_v1_length_proto_rawDescData
+}
+
+var file_bodygram_lengths_v1_length_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_bodygram_lengths_v1_length_proto_goTypes = []interface{}{
+	(*Length)(nil), // 0: bodygram.lengths.v1.Length
+}
+var file_bodygram_lengths_v1_length_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_bodygram_lengths_v1_length_proto_init() }
+func file_bodygram_lengths_v1_length_proto_init() {
+	if File_bodygram_lengths_v1_length_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bodygram_lengths_v1_length_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Length); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bodygram_lengths_v1_length_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_bodygram_lengths_v1_length_proto_goTypes,
+		DependencyIndexes: file_bodygram_lengths_v1_length_proto_depIdxs,
+		MessageInfos:      file_bodygram_lengths_v1_length_proto_msgTypes,
+	}.Build()
+	File_bodygram_lengths_v1_length_proto = out.File
+	file_bodygram_lengths_v1_length_proto_rawDesc = nil
+	file_bodygram_lengths_v1_length_proto_goTypes = nil
+	file_bodygram_lengths_v1_length_proto_depIdxs = nil
+}
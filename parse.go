@@ -0,0 +1,756 @@
+package lengths
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// unitsBySymbol maps the unit symbols accepted when parsing to their unit.
+var unitsBySymbol = map[string]Length{
+	"nm":     Nanometer,
+	"μm":     Micrometer, // U+03BC Greek small letter mu
+	"µm":     Micrometer, // U+00B5 micro sign
+	"um":     Micrometer,
+	"micron": Micrometer,
+	"mm":     Millimeter,
+	"cm":     Centimeter,
+	"dm":     Decimeter,
+	"m":      Meter,
+	"km":     Kilometer,
+	"in":     Inch,
+	"inch":   Inch,
+	"inches": Inch,
+	"ft":     Foot,
+	"foot":   Foot,
+	"feet":   Foot,
+
+	// Imperial marks, including the typographic variants found in pasted
+	// text.
+	"'":  Foot,
+	"′":  Foot, // U+2032 prime
+	"’":  Foot, // U+2019 right single quotation mark
+	"\"": Inch,
+	"''": Inch,
+	"″":  Inch, // U+2033 double prime
+	"”":  Inch, // U+201D right double quotation mark
+}
+
+// AcceptedUnitSymbols returns all the unit symbols accepted when parsing,
+// including aliases such as "um" and "micron", ordered from the shortest
+// unit to the longest, such as for populating unit selectors.
+func AcceptedUnitSymbols() []string {
+	symbols := make([]string, 0, len(unitsBySymbol))
+	for symbol := range unitsBySymbol {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		ui, uj := unitsBySymbol[symbols[i]], unitsBySymbol[symbols[j]]
+		if ui != uj {
+			return ui < uj
+		}
+		return symbols[i] < symbols[j]
+	})
+	return symbols
+}
+
+// ParseUnit parses a unit symbol, such as "cm", with the same symbols and
+// aliases accepted by ParseLength, and returns the unit. Surrounding spaces
+// are ignored. Unknown symbols return a *ParseError.
+func ParseUnit(s string) (Length, error) {
+	symbol := strings.TrimSpace(s)
+	if symbol == "" {
+		return 0, &ParseError{Input: s, Err: ErrMissingUnit}
+	}
+	unit, ok := unitsBySymbol[symbol]
+	if !ok {
+		offset := len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+		return 0, &ParseError{Input: s, Token: symbol, Offset: offset, Err: ErrUnknownUnit}
+	}
+	return unit, nil
+}
+
+// leadingInt consumes the leading [0-9]* from s. ok is false on overflow.
+func leadingInt(s string) (x uint64, rest string, ok bool) {
+	i := 0
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			break
+		}
+		hi, lo := bits.Mul64(x, 10)
+		if hi != 0 {
+			return 0, "", false
+		}
+		x, hi = bits.Add64(lo, uint64(c-'0'), 0)
+		if hi != 0 {
+			return 0, "", false
+		}
+	}
+	return x, s[i:], true
+}
+
+// leadingFraction consumes the leading [0-9]* from s. It is used only for
+// fractions, so it does not return an error on overflow: it stops
+// accumulating precision instead. The fraction is x / scale.
+func leadingFraction(s string) (x, scale uint64, rest string) {
+	i := 0
+	scale = 1
+	overflow := false
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			break
+		}
+		if overflow {
+			continue
+		}
+		if x > (1<<64-1-9)/10 || scale > (1<<64-1)/10 {
+			// It's possible for overflow to give a positive number, so take
+			// care.
+			overflow = true
+			continue
+		}
+		x = x*10 + uint64(c-'0')
+		scale *= 10
+	}
+	return x, scale, s[i:]
+}
+
+// A decimal is a non-negative decimal number whole+frac/scale as found in a
+// length string, written with digits decimals. For fractions such as 3 1/2,
+// scale is the denominator rather than a power of ten.
+type decimal struct {
+	whole  uint64
+	frac   uint64
+	scale  uint64
+	digits int
+}
+
+// A Parser parses length strings with configurable number formats. The zero
+// value parses numbers with a '.' decimal separator and no grouping, as done
+// by ParseLengthUnit.
+type Parser struct {
+	// DecimalSeparator separates the whole part of numbers from their
+	// fraction. Zero means '.'.
+	DecimalSeparator rune
+	// GroupingSeparator, if not zero, separates groups of three digits in the
+	// whole part of numbers, as ',' in "1,234.5mm". It must differ from the
+	// decimal separator.
+	GroupingSeparator rune
+	// Strict, if set, also rejects compound lengths whose units do not
+	// descend one step at a time, as in "1m5mm", which skips centimeters.
+	// The steps are km, m, cm, mm, μm and nm for metric units and ft and in
+	// for imperial units.
+	Strict bool
+	// DefaultUnit, if not zero, is the unit of bare numbers such as "178",
+	// as collected by form fields whose unit is implied.
+	DefaultUnit Length
+}
+
+// compoundSteps lists the units that may follow each unit in a compound
+// length parsed by a strict Parser.
+var compoundSteps = map[Length]Length{
+	Kilometer:  Meter,
+	Meter:      Centimeter,
+	Centimeter: Millimeter,
+	Millimeter: Micrometer,
+	Micrometer: Nanometer,
+	Foot:       Inch,
+}
+
+// Parse parses a length string as done by ParseLengthUnit with the number
+// format of the parser, so that "1.234,5mm" can be parsed with '.' as
+// grouping separator and ',' as decimal separator. Misplaced separators, such
+// as a grouping separator in the fraction, are errors.
+func (p Parser) Parse(s string) (Length, error) {
+	l, _, _, err := p.parse(s)
+	return l, err
+}
+
+// localeSeparators maps languages, and regions where they differ from their
+// language, to their decimal and grouping separators.
+var localeSeparators = map[string][2]rune{
+	"en":    {'.', ','},
+	"ja":    {'.', ','},
+	"ko":    {'.', ','},
+	"zh":    {'.', ','},
+	"de":    {',', '.'},
+	"de-ch": {'.', '’'},
+	"es":    {',', '.'},
+	"id":    {',', '.'},
+	"it":    {',', '.'},
+	"nl":    {',', '.'},
+	"pt":    {',', '.'},
+	"tr":    {',', '.'},
+	"cs":    {',', ' '},
+	"fi":    {',', ' '},
+	"fr":    {',', ' '},
+	"nb":    {',', ' '},
+	"pl":    {',', ' '},
+	"ru":    {',', ' '},
+	"sv":    {',', ' '},
+	"uk":    {',', ' '},
+}
+
+// LocaleParser returns a Parser for the number format of the given locale, a
+// BCP 47 language tag such as "de" or "fr-CA", so that "1,75 m" and
+// "1.234,5 mm" are read as written by German speakers. Locales where
+// thousands are grouped with spaces use a plain space as grouping separator.
+// ok is false if the language of the locale is not known.
+func LocaleParser(locale string) (p Parser, ok bool) {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	seps, ok := localeSeparators[locale]
+	if !ok {
+		lang, _, _ := strings.Cut(locale, "-")
+		seps, ok = localeSeparators[lang]
+	}
+	if !ok {
+		return Parser{}, false
+	}
+	return Parser{DecimalSeparator: seps[0], GroupingSeparator: seps[1]}, true
+}
+
+func (p Parser) decimalSeparator() rune {
+	if p.DecimalSeparator == 0 {
+		return '.'
+	}
+	return p.DecimalSeparator
+}
+
+// leadingDigits consumes the leading [0-9]* from s, allowing groups of three
+// digits separated by the grouping separator. A space separator is only
+// taken as grouping if it precedes three digits that are not a numerator.
+// ok is false if the groups are malformed.
+func (p Parser) leadingDigits(s string) (digits, rest string, ok bool) {
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i == -1 {
+		return s, "", true
+	}
+	if p.GroupingSeparator == 0 || i == 0 {
+		return s[:i], s[i:], true
+	}
+
+	var b strings.Builder
+	b.WriteString(s[:i])
+	s = s[i:]
+	for first := true; ; first = false {
+		r, size := utf8.DecodeRuneInString(s)
+		if r != p.GroupingSeparator {
+			break
+		}
+		group := s[size:]
+		n := strings.IndexFunc(group, func(r rune) bool { return r < '0' || r > '9' })
+		if n == -1 {
+			n = len(group)
+		}
+		if n == 0 {
+			// The separator isn't part of the number, such as a space
+			// before the unit.
+			break
+		}
+		if p.GroupingSeparator == ' ' && (n != 3 || strings.HasPrefix(group[n:], "/")) {
+			// A space also separates whole numbers from fractions, as in
+			// "3 1/2 in" or "3 125/1000 in".
+			break
+		}
+		if n != 3 || first && i > 3 {
+			return "", "", false
+		}
+		b.WriteString(group[:n])
+		s = group[n:]
+	}
+	return b.String(), s, true
+}
+
+// leadingDecimal consumes the leading decimal number ([0-9]*(\.[0-9]*)?)
+// from s. ok is false if s does not start with a number, if its integer part
+// overflows or if its separators are misplaced.
+func (p Parser) leadingDecimal(s string) (d decimal, rest string, ok bool) {
+	whole, s, ok := p.leadingDigits(s)
+	if !ok {
+		return decimal{}, "", false
+	}
+	if d.whole, _, ok = leadingInt(whole); !ok {
+		return decimal{}, "", false
+	}
+	pre := whole != "" // whether we consumed anything before a period
+	d.scale = 1
+	post := false // whether we consumed anything after a period
+	if r, size := utf8.DecodeRuneInString(s); r == p.decimalSeparator() {
+		s = s[size:]
+		pl := len(s)
+		d.frac, d.scale, s = leadingFraction(s)
+		d.digits = pl - len(s)
+		post = d.digits > 0
+		if r, size := utf8.DecodeRuneInString(s); r == p.GroupingSeparator && post {
+			if next, _ := utf8.DecodeRuneInString(s[size:]); '0' <= next && next <= '9' {
+				// grouping in the fraction (e.g. "1.234,5mm" with ','
+				// grouping)
+				return decimal{}, "", false
+			}
+		}
+	}
+	if !pre && !post {
+		// no digits (e.g. ".cm" or "cm")
+		return decimal{}, "", false
+	}
+	return d, s, true
+}
+
+// maxExponent bounds the exponents of numbers in scientific notation, far
+// beyond those of representable lengths in any unit.
+const maxExponent = 40
+
+// withExponent consumes the exponent following the number d from s, as
+// "e2" in "1.75e2cm" or "E-3" in "2.54E-3m", and returns the number scaled by
+// it. s is returned unchanged if it does not start with an exponent. ok is
+// false if the exponent is out of range.
+func (d decimal) withExponent(s string) (e decimal, rest string, ok bool) {
+	if s == "" || s[0] != 'e' && s[0] != 'E' {
+		return d, s, true
+	}
+	t := s[1:]
+	neg := strings.HasPrefix(t, "-")
+	if neg || strings.HasPrefix(t, "+") {
+		t = t[1:]
+	}
+	exp, rest, ok := leadingInt(t)
+	if rest == t {
+		// Not an exponent.
+		return d, s, true
+	}
+	if !ok || exp > maxExponent {
+		return decimal{}, "", false
+	}
+
+	// Move the decimal point of the digits of d by exp places.
+	whole := strconv.FormatUint(d.whole, 10)
+	frac := ""
+	if d.scale > 1 {
+		frac = strconv.FormatUint(d.scale+d.frac, 10)[1:]
+	}
+	if neg {
+		whole = strings.Repeat("0", int(exp)) + whole
+		frac = whole[len(whole)-int(exp):] + frac
+		whole = whole[:len(whole)-int(exp)]
+	} else {
+		if len(frac) < int(exp) {
+			frac += strings.Repeat("0", int(exp)-len(frac))
+		}
+		whole += frac[:exp]
+		frac = frac[exp:]
+	}
+	if frac != "" {
+		whole += "." + frac
+	}
+	if e, r, ok := (Parser{}).leadingDecimal(whole); ok && r == "" {
+		return e, rest, true
+	}
+	return decimal{}, "", false
+}
+
+// withFraction consumes the fraction following the whole number d from s, as
+// in the imperial notations "15/16", "3 1/2" and "1-3/4". s is returned
+// unchanged if it does not start with a fraction. ok is false if the fraction
+// is malformed, such as with a zero denominator.
+func (d decimal) withFraction(s string) (f decimal, rest string, ok bool) {
+	if d.scale != 1 || d.digits != 0 {
+		return d, s, true
+	}
+	if strings.HasPrefix(s, "/") {
+		// Simple fraction, possibly improper as 3/2.
+		den, rest, ok := leadingInt(s[1:])
+		if !ok || den == 0 || rest == s[1:] {
+			return decimal{}, "", false
+		}
+		return decimal{whole: d.whole / den, frac: d.whole % den, scale: den}, rest, true
+	}
+	if s == "" || s[0] != ' ' && s[0] != '-' {
+		return d, s, true
+	}
+	num, after, ok := leadingInt(strings.TrimLeft(s[1:], " "))
+	if !ok || !strings.HasPrefix(after, "/") {
+		return d, s, true
+	}
+	den, rest, ok := leadingInt(after[1:])
+	if !ok || rest == after[1:] || num >= den {
+		return decimal{}, "", false
+	}
+	d.frac, d.scale = num, den
+	return d, rest, true
+}
+
+// length returns the length of d units, with the fraction rounded to the
+// closest nanometer. ok is false on overflow.
+func (d decimal) length(unit Length) (l Length, ok bool) {
+	hi, n := bits.Mul64(d.whole, uint64(unit))
+	if hi != 0 {
+		return 0, false
+	}
+	if d.frac > 0 {
+		// frac < scale, so the quotient is less than unit and Div64 cannot
+		// panic.
+		hi, lo := bits.Mul64(d.frac, uint64(unit))
+		lo, carry := bits.Add64(lo, d.scale/2, 0)
+		f, _ := bits.Div64(hi+carry, lo, d.scale)
+		n, hi = bits.Add64(n, f, 0)
+		if hi != 0 {
+			return 0, false
+		}
+	}
+	return Length(n), true
+}
+
+// ParseLength parses a length string such as "1.75m", "178cm" or 5'10", as
+// formatted by String, and returns the length. It accepts the same strings as
+// ParseLengthUnit. Malformed strings return a *ParseError.
+func ParseLength(s string) (Length, error) {
+	l, _, _, err := Parser{}.parse(s)
+	return l, err
+}
+
+// ParseLengthUnit parses a length string made of a decimal number and a unit
+// symbol, such as "178cm", "1.78 m" or "70in", and returns the length and
+// the unit that matched, so that the length can be echoed back in the unit
+// it was entered in. Valid units are "nm", "um" (or "μm" or "micron"), "mm",
+// "cm", "dm", "m", "km", "in" (or '"', "inch" or "inches") and "ft" (or "'",
+// "foot" or "feet"). The length is rounded to the closest nanometer. The
+// string "0" is accepted without unit, with a zero unit.
+//
+// Numbers may be written in scientific notation, as in "1.75e2cm" or
+// "2.54E-3 m". Whole numbers may be followed by a fraction, as in "15/16in",
+// "3 1/2 in" or 1-3/4", which is converted exactly before rounding.
+//
+// The string may also be a sequence of such numbers and units, such as
+// 5'10" or "5 feet 10 inches" for 5 feet and 10 inches, or "1m 75cm", in
+// which case the unit that matched is the last one. Units must descend:
+// repeated or ascending units, as in "1m 1m" or "5in 5ft", are errors. The
+// last number may omit its unit in the common shorthands 5'10, for 5'10", and
+// "1m75", for 1.75m, after a whole number of feet or meters. Typographic
+// primes and quotes, as in 5′10″ or 5’10”, are accepted in place of the
+// apostrophe and quotation mark.
+func ParseLengthUnit(s string) (Length, Length, error) {
+	l, unit, _, err := Parser{}.parse(s)
+	return l, unit, err
+}
+
+// ParseLengthPrecision parses a length string like ParseLengthUnit and
+// returns the length and the number of decimals written in the string, so
+// that the length can be echoed back with the precision it was entered with:
+// "1.8m" has 1 decimal, "1.80000m" has 5 and "180cm" has none.
+func ParseLengthPrecision(s string) (Length, int, error) {
+	l, _, decimals, err := Parser{}.parse(s)
+	return l, decimals, err
+}
+
+// Errors reported by ParseError, usable with errors.Is.
+var (
+	ErrSyntax      = errors.New("invalid syntax")
+	ErrUnknownUnit = errors.New("unknown unit")
+	ErrMissingUnit = errors.New("missing unit")
+	ErrNegative    = errors.New("negative length")
+	ErrRange       = errors.New("length out of range")
+)
+
+// A ParseError records a failure to parse a length string, so that callers
+// can point end users to the offending part of their input.
+type ParseError struct {
+	Input  string // the string being parsed
+	Token  string // the offending part of Input
+	Offset int    // the byte offset of Token in Input
+	Err    error  // the reason, such as ErrSyntax or ErrUnknownUnit
+}
+
+func (e *ParseError) Error() string {
+	msg := "lengths: " + e.Err.Error()
+	if e.Token != "" {
+		msg += " " + strconv.Quote(e.Token) + " at offset " + strconv.Itoa(e.Offset)
+	}
+	return msg + " in length " + strconv.Quote(e.Input)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parse parses a length string made of a sequence of decimal numbers each
+// followed by a unit symbol and returns the total length, the unit and the
+// number of decimals of the last number. Malformed strings return a
+// *ParseError.
+func (p Parser) parse(s string) (l, unit Length, decimals int, err error) {
+	orig := s
+	if p.GroupingSeparator == p.decimalSeparator() {
+		return 0, 0, 0, errors.New("lengths: grouping separator conflicts with decimal separator")
+	}
+
+	// end is the input without trailing spaces, of which s is a suffix from
+	// now on, so that offsets can be computed from the length of s.
+	end := strings.TrimRightFunc(s, unicode.IsSpace)
+	s = strings.TrimLeftFunc(end, unicode.IsSpace)
+	fail := func(err error, token string) (Length, Length, int, error) {
+		return 0, 0, 0, &ParseError{Input: orig, Token: token, Offset: len(end) - len(s), Err: err}
+	}
+	if s != "" && s[0] == '-' {
+		return fail(ErrNegative, "-")
+	}
+	s = strings.TrimPrefix(s, "+")
+	if s == "" {
+		return fail(ErrSyntax, "")
+	}
+	if s == "0" {
+		// As formatted by String.
+		return 0, 0, 0, nil
+	}
+
+	whole := false // whether the previous number was a whole number
+	for s != "" {
+		term := s
+		reason := ErrSyntax
+		d, rest, ok := p.leadingDecimal(s)
+		if ok {
+			if d, rest, ok = d.withExponent(rest); !ok {
+				// The exponent moves the point too far.
+				reason = ErrRange
+			}
+		}
+		if ok {
+			d, rest, ok = d.withFraction(rest)
+		}
+		if !ok {
+			word, _, _ := strings.Cut(s, " ")
+			if _, _, ok := leadingInt(s); !ok {
+				reason = ErrRange
+			}
+			return fail(reason, word)
+		}
+		number := s[:len(s)-len(rest)]
+		s = strings.TrimLeft(rest, " ")
+
+		// Consume the unit, which runs up to the next number.
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return r == ' ' || r == '.' || '0' <= r && r <= '9'
+		})
+		if i == -1 {
+			i = len(s)
+		}
+		symbol := s[:i]
+		if symbol == "" {
+			switch {
+			case s == "" && unit == 0 && p.DefaultUnit != 0:
+				// A bare number is in the default unit.
+				unit = p.DefaultUnit
+			case s == "" && d.scale == 1 && whole:
+				// A trailing number may omit its unit, as in "2m75" or 5'10.
+				d, unit, ok = shorthand(number, unit)
+			default:
+				ok = false
+			}
+			if !ok {
+				s = term
+				return fail(ErrMissingUnit, number)
+			}
+		} else {
+			prev := unit
+			unit, ok = unitsBySymbol[symbol]
+			if !ok {
+				return fail(ErrUnknownUnit, symbol)
+			}
+			// Units of compound lengths descend, as in "1m 75cm" or 5'10".
+			if prev != 0 && (unit >= prev || p.Strict && compoundSteps[prev] != unit) {
+				return fail(ErrSyntax, symbol)
+			}
+		}
+
+		v, ok := d.length(unit)
+		if ok {
+			l, ok = Sum(l, v)
+		}
+		if !ok {
+			token := strings.TrimSpace(term[:len(term)-len(s)+i])
+			s = term
+			return fail(ErrRange, token)
+		}
+		s = strings.TrimLeft(s[i:], " ")
+		decimals = d.digits
+		whole = d.scale == 1 && d.digits == 0
+	}
+	return l, unit, decimals, nil
+}
+
+// shorthand returns the value of the unitless whole number written as digits
+// following a whole number of prev units, and its unit. After feet, the
+// number counts inches, as in 5'10. After meters, the digits are decimals of
+// the meter, as in "2m75" for 2.75m or "1m5" for 1.5m. ok is false after
+// other units.
+func shorthand(digits string, prev Length) (d decimal, unit Length, ok bool) {
+	switch prev {
+	case Foot:
+		whole, rest, ok := leadingInt(digits)
+		return decimal{whole: whole, scale: 1}, Inch, ok && rest == ""
+	case Meter:
+		frac, scale, rest := leadingFraction(digits)
+		return decimal{frac: frac, scale: scale, digits: len(digits)}, Meter, rest == ""
+	default:
+		return decimal{}, 0, false
+	}
+}
+
+// ParseRange parses a range of lengths such as "150cm-200cm", as submitted
+// by filter forms, and returns its bounds. The unit may be shared by both
+// bounds, as in "150-200cm". Either bound may be omitted to leave the range
+// open on that side: "150cm-" returns MaxLength as max and "-200cm" returns
+// zero as min.
+//
+// As lengths cannot be negative, the dash is always the separator: "-200cm"
+// is the range up to 200cm, not a negative length.
+//
+// Malformed ranges return a *ParseError locating the offending part in the
+// whole range. Inverted ranges, such as "200cm-150cm", report ErrSyntax at
+// the maximum.
+func ParseRange(s string) (min, max Length, err error) {
+	fail := func(token string, offset int, err error) (Length, Length, error) {
+		return 0, 0, &ParseError{Input: s, Token: token, Offset: offset, Err: err}
+	}
+	dash := -1
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' || isExponentSign(s, i) {
+			continue
+		}
+		if dash != -1 {
+			return fail("-", i, ErrSyntax)
+		}
+		dash = i
+	}
+	if dash == -1 {
+		return fail("", 0, ErrSyntax)
+	}
+	lo, hi := strings.TrimSpace(s[:dash]), strings.TrimSpace(s[dash+1:])
+	if lo == "" && hi == "" {
+		return fail("-", dash, ErrSyntax)
+	}
+	loOffset := len(s[:dash]) - len(strings.TrimLeftFunc(s[:dash], unicode.IsSpace))
+	hiOffset := len(s) - len(strings.TrimLeftFunc(s[dash+1:], unicode.IsSpace))
+
+	max = MaxLength
+	var unit Length
+	if hi != "" {
+		if max, unit, err = ParseLengthUnit(hi); err != nil {
+			return 0, 0, inRange(err, s, hiOffset)
+		}
+	}
+	if lo != "" {
+		// A bare number shares the unit of the maximum.
+		if d, rest, ok := (Parser{}).leadingDecimal(lo); ok && rest == "" && unit != 0 {
+			if min, ok = d.length(unit); !ok {
+				return fail(lo, loOffset, ErrRange)
+			}
+		} else if min, _, err = ParseLengthUnit(lo); err != nil {
+			return 0, 0, inRange(err, s, loOffset)
+		}
+	}
+	if min > max {
+		// The maximum is shorter than the minimum.
+		return fail(hi, hiOffset, ErrSyntax)
+	}
+	return min, max, nil
+}
+
+// inRange returns err, the error parsing a bound of the range s starting at
+// offset, with its *ParseError relative to the whole range.
+func inRange(err error, s string, offset int) error {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		return err
+	}
+	e := *perr
+	e.Input, e.Offset = s, e.Offset+offset
+	return &e
+}
+
+// isExponentSign reports whether the byte at i in s is the sign of the
+// exponent of a number in scientific notation, as in "2.54e-3".
+func isExponentSign(s string, i int) bool {
+	return i >= 2 && (s[i-1] == 'e' || s[i-1] == 'E') && ('0' <= s[i-2] && s[i-2] <= '9' || s[i-2] == '.')
+}
+
+// NormalizeString parses a length string as done by ParseLengthUnit and
+// returns it in canonical form in the unit it was entered in, without
+// redundant zeros and with the canonical unit symbol: "178.000cm" becomes
+// "178cm" and "12.5 um" becomes "12.5μm". It helps deduplicating display
+// strings.
+func NormalizeString(s string) (string, error) {
+	l, unit, err := ParseLengthUnit(s)
+	if err != nil {
+		return "", err
+	}
+	return l.FormatUnit(unit), nil
+}
+
+// SumLabeled reads lines of labeled lengths such as "shelf: 1.2m" from r, as
+// pasted from a shopping list, and returns their total and the total of each
+// label. Lengths are parsed as done by ParseLengthUnit and blank lines are
+// ignored. Errors are prefixed with the number of the offending line.
+func SumLabeled(r io.Reader) (Length, map[string]Length, error) {
+	var total Length
+	totals := make(map[string]Length)
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		l, label, err := parseLabeled(line)
+		if err != nil {
+			return 0, nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		var ok1, ok2 bool
+		if total, ok1 = Sum(total, l); ok1 {
+			totals[label], ok2 = Sum(totals[label], l)
+		}
+		if !ok1 || !ok2 {
+			return 0, nil, fmt.Errorf("line %d: lengths: total overflows", n)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, nil, err
+	}
+	return total, totals, nil
+}
+
+// parseLabeled parses a "label: length" line.
+func parseLabeled(line string) (Length, string, error) {
+	label, value, found := strings.Cut(line, ":")
+	label = strings.TrimSpace(label)
+	if !found || label == "" {
+		return 0, "", errors.New("lengths: missing label in " + strconv.Quote(line))
+	}
+	l, _, err := ParseLengthUnit(value)
+	return l, label, err
+}
+
+// MustParseLength is like ParseLength but panics if s cannot be parsed. It
+// simplifies the initialization of package-level variables and tables from
+// known-good constants:
+//
+//	var maxReach = lengths.MustParseLength("2.5m")
+func MustParseLength(s string) Length {
+	l, err := ParseLength(s)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// L is a terse alias of MustParseLength for tests and table setup, such as
+// L("1.234567mm"). Like MustParseLength, it panics if s cannot be parsed.
+func L(s string) Length {
+	return MustParseLength(s)
+}
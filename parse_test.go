@@ -0,0 +1,1008 @@
+package lengths
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseLengthUnit(t *testing.T) {
+	testCases := []struct {
+		s        string
+		want     Length
+		wantUnit Length
+	}{
+		{
+			s:        "70in",
+			want:     70 * Inch,
+			wantUnit: Inch,
+		},
+		{
+			s:        "70.5 in",
+			want:     70*Inch + Inch/2,
+			wantUnit: Inch,
+		},
+		{
+			s:        "178cm",
+			want:     178 * Centimeter,
+			wantUnit: Centimeter,
+		},
+		{
+			s:        " 1.78 m ",
+			want:     178 * Centimeter,
+			wantUnit: Meter,
+		},
+		{
+			s:        "1.234567mm",
+			want:     1234567 * Nanometer,
+			wantUnit: Millimeter,
+		},
+		{
+			s:        "12.345μm",
+			want:     12345 * Nanometer,
+			wantUnit: Micrometer,
+		},
+		{
+			s:        "12.345um",
+			want:     12345 * Nanometer,
+			wantUnit: Micrometer,
+		},
+		{
+			s:        "123nm",
+			want:     123 * Nanometer,
+			wantUnit: Nanometer,
+		},
+		{
+			s:        ".5km",
+			want:     500 * Meter,
+			wantUnit: Kilometer,
+		},
+		{
+			s:        "6ft",
+			want:     6 * Foot,
+			wantUnit: Foot,
+		},
+		{
+			s:        "0.0000000005m",
+			want:     1 * Nanometer,
+			wantUnit: Meter,
+		},
+		{
+			s:        "7654321km",
+			want:     7654321 * Kilometer,
+			wantUnit: Kilometer,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotUnit, err := ParseLengthUnit(tc.s)
+		if err != nil {
+			t.Errorf("ParseLengthUnit(%q): unexpected error: %v", tc.s, err)
+			continue
+		}
+		if got != tc.want || gotUnit != tc.wantUnit {
+			t.Errorf(
+				"ParseLengthUnit(%q): got %q, %q, want %q, %q",
+				tc.s,
+				got,
+				gotUnit,
+				tc.want,
+				tc.wantUnit,
+			)
+		}
+	}
+}
+
+func TestParseLength(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want Length
+	}{
+		{s: "1.75m", want: 175 * Centimeter},
+		{s: "178cm", want: 178 * Centimeter},
+		{s: "25.4mm", want: 1 * Inch},
+		{s: "70in", want: 70 * Inch},
+		{s: "6ft", want: 6 * Foot},
+		{s: "0", want: 0},
+		{s: "5'10\"", want: 5*Foot + 10*Inch},
+		{s: "5' 10\"", want: 5*Foot + 10*Inch},
+		{s: "5'10.5\"", want: 5*Foot + 105*Inch/10},
+		{s: "5ft 10in", want: 5*Foot + 10*Inch},
+		{s: "5ft10in", want: 5*Foot + 10*Inch},
+		{s: "5 ft 10.5 in", want: 5*Foot + 105*Inch/10},
+		{s: "5 feet 10 inches", want: 5*Foot + 10*Inch},
+		{s: "1 foot 1 inch", want: 1*Foot + 1*Inch},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseLength(tc.s)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseLength(%q): got %q, %v, want %q", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func TestParseLengthFraction(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want Length
+	}{
+		{s: "3 1/2 in", want: 3*Inch + Inch/2},
+		{s: "3 1/2in", want: 3*Inch + Inch/2},
+		{s: "1-3/4\"", want: 1*Inch + 3*Inch/4},
+		{s: "15/16in", want: 15 * Inch / 16},
+		{s: "3/2 in", want: 1*Inch + Inch/2},
+		{s: "5' 7 3/8\"", want: 5*Foot + 7*Inch + 3*Inch/8},
+		{s: "1/3in", want: 8466667 * Nanometer},
+		{s: "2/3in", want: 16933333 * Nanometer},
+		{s: "1 1/4 m", want: 125 * Centimeter},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseLength(tc.s)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseLength(%q): got %q, %v, want %q", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func TestParseLengthFractionErrors(t *testing.T) {
+	for _, s := range []string{
+		"1/0in",
+		"1/in",
+		"3 5/4in",
+		"3 1/0in",
+		"3 1/in",
+		"1.5/2in",
+	} {
+		if got, err := ParseLength(s); err == nil {
+			t.Errorf("ParseLength(%q): got %q, want error", s, got)
+		}
+	}
+}
+
+func TestParseLengthCompound(t *testing.T) {
+	testCases := []struct {
+		s            string
+		want         Length
+		wantUnit     Length
+		wantDecimals int
+	}{
+		{s: "1m 75cm", want: 175 * Centimeter, wantUnit: Centimeter, wantDecimals: 0},
+		{s: "1m75cm", want: 175 * Centimeter, wantUnit: Centimeter, wantDecimals: 0},
+		{s: "1m 75cm 5mm", want: 1755 * Millimeter, wantUnit: Millimeter, wantDecimals: 0},
+		{s: "2m75", want: 275 * Centimeter, wantUnit: Meter, wantDecimals: 2},
+		{s: "1m5", want: 150 * Centimeter, wantUnit: Meter, wantDecimals: 1},
+		{s: "2m05", want: 205 * Centimeter, wantUnit: Meter, wantDecimals: 2},
+		{s: "5'10", want: 5*Foot + 10*Inch, wantUnit: Inch, wantDecimals: 0},
+		{s: "5ft 10", want: 5*Foot + 10*Inch, wantUnit: Inch, wantDecimals: 0},
+	}
+
+	for _, tc := range testCases {
+		got, gotUnit, gotDecimals, err := Parser{}.parse(tc.s)
+		if err != nil || got != tc.want || gotUnit != tc.wantUnit || gotDecimals != tc.wantDecimals {
+			t.Errorf(
+				"parse(%q): got %q, %q, %d, %v, want %q, %q, %d",
+				tc.s,
+				got,
+				gotUnit,
+				gotDecimals,
+				err,
+				tc.want,
+				tc.wantUnit,
+				tc.wantDecimals,
+			)
+		}
+	}
+}
+
+func TestParseLengthCompoundErrors(t *testing.T) {
+	for _, s := range []string{
+		"178",
+		"70in5",
+		"2m75 5cm",
+		"2m7.5",
+		"5'10 1/2",
+	} {
+		if got, err := ParseLength(s); err == nil {
+			t.Errorf("ParseLength(%q): got %q, want error", s, got)
+		}
+	}
+}
+
+func TestParseLengthShorthandErrors(t *testing.T) {
+	// The shorthand only follows a whole number of meters or feet.
+	for _, s := range []string{
+		"1.5m5",
+		"1m75cm5",
+		"12cm5",
+		"1km5",
+		"5.5ft 6",
+		"1/2ft 6",
+	} {
+		if got, err := ParseLength(s); !errors.Is(err, ErrMissingUnit) {
+			t.Errorf("ParseLength(%q): got %q, %v, want ErrMissingUnit", s, got, err)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	testCases := []struct {
+		s          string
+		wantErr    error
+		wantToken  string
+		wantOffset int
+	}{
+		{s: "", wantErr: ErrSyntax, wantToken: "", wantOffset: 0},
+		{s: "  ", wantErr: ErrSyntax, wantToken: "", wantOffset: 0},
+		{s: "-5cm", wantErr: ErrNegative, wantToken: "-", wantOffset: 0},
+		{s: " -5cm", wantErr: ErrNegative, wantToken: "-", wantOffset: 1},
+		{s: "tall", wantErr: ErrSyntax, wantToken: "tall", wantOffset: 0},
+		{s: "5ft tall", wantErr: ErrSyntax, wantToken: "tall", wantOffset: 4},
+		{s: "1/0in", wantErr: ErrSyntax, wantToken: "1/0in", wantOffset: 0},
+		{s: "178", wantErr: ErrMissingUnit, wantToken: "178", wantOffset: 0},
+		{s: "1m 75 5cm", wantErr: ErrMissingUnit, wantToken: "75", wantOffset: 3},
+		{s: "5 cubits", wantErr: ErrUnknownUnit, wantToken: "cubits", wantOffset: 2},
+		{s: "1m 5yd ", wantErr: ErrUnknownUnit, wantToken: "yd", wantOffset: 4},
+		{s: "99999999999km", wantErr: ErrRange, wantToken: "99999999999km", wantOffset: 0},
+		{s: "1m 18446744073709551615 nm", wantErr: ErrRange, wantToken: "18446744073709551615 nm", wantOffset: 3},
+		{s: "99999999999999999999nm", wantErr: ErrRange, wantToken: "99999999999999999999nm", wantOffset: 0},
+		{s: "1e40m", wantErr: ErrRange, wantToken: "1e40m", wantOffset: 0},
+		{s: "1m 1e41 cm", wantErr: ErrRange, wantToken: "1e41", wantOffset: 3},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseLength(tc.s)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseLength(%q): got error %v, want a *ParseError", tc.s, err)
+			continue
+		}
+		if !errors.Is(err, tc.wantErr) || perr.Input != tc.s || perr.Token != tc.wantToken || perr.Offset != tc.wantOffset {
+			t.Errorf(
+				"ParseLength(%q): got %v, %q, %q, %d, want %v, %q, %q, %d",
+				tc.s,
+				perr.Err,
+				perr.Input,
+				perr.Token,
+				perr.Offset,
+				tc.wantErr,
+				tc.s,
+				tc.wantToken,
+				tc.wantOffset,
+			)
+		}
+	}
+
+	_, err := ParseLength("5 cubits")
+	if got, want := err.Error(), `lengths: unknown unit "cubits" at offset 2 in length "5 cubits"`; got != want {
+		t.Errorf("Error(): got %q, want %q", got, want)
+	}
+}
+
+func TestParserStrictError(t *testing.T) {
+	_, err := Parser{Strict: true}.Parse("1m 5mm")
+	var perr *ParseError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrSyntax) || perr.Token != "mm" || perr.Offset != 4 {
+		t.Errorf("Parse(%q): got %#v, want a syntax error at \"mm\"", "1m 5mm", err)
+	}
+}
+
+func TestParseLengthScientific(t *testing.T) {
+	testCases := []struct {
+		s            string
+		want         Length
+		wantDecimals int
+	}{
+		{s: "1.75e2 cm", want: 175 * Centimeter, wantDecimals: 0},
+		{s: "1.75e2cm", want: 175 * Centimeter, wantDecimals: 0},
+		{s: "2.54E-3 m", want: 254 * Millimeter / 100, wantDecimals: 5},
+		{s: "1e3mm", want: 1 * Meter, wantDecimals: 0},
+		{s: "1E+3mm", want: 1 * Meter, wantDecimals: 0},
+		{s: "1.2345e2mm", want: 123450 * Micrometer, wantDecimals: 2},
+		{s: "1.75e0m", want: 175 * Centimeter, wantDecimals: 2},
+		{s: "5e-10m", want: 1 * Nanometer, wantDecimals: 10},
+		{s: ".5e1m", want: 5 * Meter, wantDecimals: 0},
+		{s: "1.5e1ft 3e0in", want: 15*Foot + 3*Inch, wantDecimals: 0},
+	}
+
+	for _, tc := range testCases {
+		got, gotDecimals, err := ParseLengthPrecision(tc.s)
+		if err != nil || got != tc.want || gotDecimals != tc.wantDecimals {
+			t.Errorf("ParseLengthPrecision(%q): got %q, %d, %v, want %q, %d", tc.s, got, gotDecimals, err, tc.want, tc.wantDecimals)
+		}
+	}
+
+	for _, s := range []string{"1e99m", "1e20km", "1e-m", "1em"} {
+		if got, err := ParseLength(s); err == nil {
+			t.Errorf("ParseLength(%q): got %q, want error", s, got)
+		}
+	}
+
+	min, max, err := ParseRange("1e-3m-2.5e-3m")
+	if err != nil || min != 1*Millimeter || max != 25*Millimeter/10 {
+		t.Errorf("ParseRange(%q): got %q, %q, %v, want %q, %q", "1e-3m-2.5e-3m", min, max, err, 1*Millimeter, 25*Millimeter/10)
+	}
+	if got, err := Eval("2.54e-2m * 1e1 - 4e-2m"); err != nil || got != 214*Millimeter {
+		t.Errorf("Eval(%q): got %q, %v, want %q", "2.54e-2m * 1e1 - 4e-2m", got, err, 214*Millimeter)
+	}
+}
+
+func TestParseLengthString(t *testing.T) {
+	for _, l := range []Length{
+		0,
+		123 * Nanometer,
+		12345 * Nanometer,
+		1234567 * Nanometer,
+		42 * Centimeter,
+		178 * Centimeter,
+		42195 * Meter,
+	} {
+		got, err := ParseLength(l.String())
+		if err != nil || got != l {
+			t.Errorf("ParseLength(%q): got %q, %v, want %q", l.String(), got, err, l)
+		}
+	}
+}
+
+func TestParseLengthUnitErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"cm",
+		".cm",
+		"178",
+		"-178cm",
+		"178 furlongs",
+		"1.7.8m",
+		"99999999999999999999nm",
+		"20000000km",
+	} {
+		if _, _, err := ParseLengthUnit(s); err == nil {
+			t.Errorf("ParseLengthUnit(%q): expected an error", s)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		s       string
+		wantMin Length
+		wantMax Length
+	}{
+		{
+			s:       "150cm-200cm",
+			wantMin: 150 * Centimeter,
+			wantMax: 200 * Centimeter,
+		},
+		{
+			s:       "1.5m - 2m",
+			wantMin: 150 * Centimeter,
+			wantMax: 200 * Centimeter,
+		},
+		{
+			s:       "150cm-2m",
+			wantMin: 150 * Centimeter,
+			wantMax: 200 * Centimeter,
+		},
+		{
+			s:       "150-200cm",
+			wantMin: 150 * Centimeter,
+			wantMax: 200 * Centimeter,
+		},
+		{
+			s:       "150cm-",
+			wantMin: 150 * Centimeter,
+			wantMax: MaxLength,
+		},
+		{
+			s:       "-200cm",
+			wantMin: 0,
+			wantMax: 200 * Centimeter,
+		},
+		{
+			s:       "178cm-178cm",
+			wantMin: 178 * Centimeter,
+			wantMax: 178 * Centimeter,
+		},
+	}
+
+	for _, tc := range testCases {
+		gotMin, gotMax, err := ParseRange(tc.s)
+		if err != nil {
+			t.Errorf("ParseRange(%q): unexpected error: %v", tc.s, err)
+			continue
+		}
+		if gotMin != tc.wantMin || gotMax != tc.wantMax {
+			t.Errorf(
+				"ParseRange(%q): got %q, %q, want %q, %q",
+				tc.s,
+				gotMin,
+				gotMax,
+				tc.wantMin,
+				tc.wantMax,
+			)
+		}
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"-",
+		"150cm",
+		"150-",
+		"150cm-200",
+		"150cm-200cm-250cm",
+		"200cm-150cm",
+		"150cm-200 furlongs",
+	} {
+		if _, _, err := ParseRange(s); err == nil {
+			t.Errorf("ParseRange(%q): expected an error", s)
+		}
+	}
+}
+
+func TestParseRangeParseError(t *testing.T) {
+	testCases := []struct {
+		s          string
+		wantErr    error
+		wantToken  string
+		wantOffset int
+	}{
+		{s: "150cm", wantErr: ErrSyntax, wantToken: "", wantOffset: 0},
+		{s: " - ", wantErr: ErrSyntax, wantToken: "-", wantOffset: 1},
+		{s: "150cm-200cm-250cm", wantErr: ErrSyntax, wantToken: "-", wantOffset: 11},
+		{s: "200cm-150cm", wantErr: ErrSyntax, wantToken: "150cm", wantOffset: 6},
+		{s: "150-200", wantErr: ErrMissingUnit, wantToken: "200", wantOffset: 4},
+		{s: "150cm - 200 furlongs", wantErr: ErrUnknownUnit, wantToken: "furlongs", wantOffset: 12},
+		{s: " tall-200cm", wantErr: ErrSyntax, wantToken: "tall", wantOffset: 1},
+		{s: "99999999999-1km", wantErr: ErrRange, wantToken: "99999999999", wantOffset: 0},
+	}
+
+	for _, tc := range testCases {
+		_, _, err := ParseRange(tc.s)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseRange(%q): got error %v, want a *ParseError", tc.s, err)
+			continue
+		}
+		if !errors.Is(err, tc.wantErr) || perr.Input != tc.s || perr.Token != tc.wantToken || perr.Offset != tc.wantOffset {
+			t.Errorf(
+				"ParseRange(%q): got %v, %q, %q, %d, want %v, %q, %q, %d",
+				tc.s,
+				perr.Err,
+				perr.Input,
+				perr.Token,
+				perr.Offset,
+				tc.wantErr,
+				tc.s,
+				tc.wantToken,
+				tc.wantOffset,
+			)
+		}
+	}
+
+	_, _, err := ParseRange("150-200")
+	if got, want := err.Error(), `lengths: missing unit "200" at offset 4 in length "150-200"`; got != want {
+		t.Errorf("Error(): got %q, want %q", got, want)
+	}
+}
+
+func TestParseLengthPrecision(t *testing.T) {
+	testCases := []struct {
+		s            string
+		want         Length
+		wantDecimals int
+	}{
+		{
+			s:            "180cm",
+			want:         180 * Centimeter,
+			wantDecimals: 0,
+		},
+		{
+			s:            "180.cm",
+			want:         180 * Centimeter,
+			wantDecimals: 0,
+		},
+		{
+			s:            "1.8m",
+			want:         180 * Centimeter,
+			wantDecimals: 1,
+		},
+		{
+			s:            "1.80000m",
+			want:         180 * Centimeter,
+			wantDecimals: 5,
+		},
+		{
+			s:            "70.25in",
+			want:         70*Inch + Inch/4,
+			wantDecimals: 2,
+		},
+		{
+			s:            "0.1234567890123456789012m",
+			want:         123456789 * Nanometer,
+			wantDecimals: 22,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotDecimals, err := ParseLengthPrecision(tc.s)
+		if err != nil {
+			t.Errorf("ParseLengthPrecision(%q): unexpected error: %v", tc.s, err)
+			continue
+		}
+		if got != tc.want || gotDecimals != tc.wantDecimals {
+			t.Errorf(
+				"ParseLengthPrecision(%q): got %q, %d, want %q, %d",
+				tc.s,
+				got,
+				gotDecimals,
+				tc.want,
+				tc.wantDecimals,
+			)
+		}
+	}
+}
+
+func TestParseLengthUnitImperialMarks(t *testing.T) {
+	want := 5*Foot + 10*Inch
+	for _, s := range []string{
+		`5'10"`,
+		`5' 10"`,
+		`5'10''`,
+		"5′10″",
+		"5’10”",
+		"5ft10in",
+		"5ft 10 in",
+	} {
+		got, gotUnit, err := ParseLengthUnit(s)
+		if err != nil {
+			t.Errorf("ParseLengthUnit(%q): unexpected error: %v", s, err)
+			continue
+		}
+		if got != want || gotUnit != Inch {
+			t.Errorf("ParseLengthUnit(%q): got %q, %q, want %q, %q", s, got, gotUnit, want, Inch)
+		}
+	}
+
+	for _, tc := range []struct {
+		s    string
+		want Length
+	}{
+		{
+			s:    "6′",
+			want: 6 * Foot,
+		},
+		{
+			s:    "10.5″",
+			want: 10*Inch + Inch/2,
+		},
+	} {
+		if got, _, err := ParseLengthUnit(tc.s); err != nil || got != tc.want {
+			t.Errorf("ParseLengthUnit(%q): got %q, %v, want %q", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func TestParserGrouping(t *testing.T) {
+	us := Parser{GroupingSeparator: ','}
+	eu := Parser{DecimalSeparator: ',', GroupingSeparator: '.'}
+	fr := Parser{DecimalSeparator: ',', GroupingSeparator: ' '}
+	testCases := []struct {
+		p    Parser
+		s    string
+		want Length
+	}{
+		{
+			p:    us,
+			s:    "1,234.5mm",
+			want: 12345 * Millimeter / 10,
+		},
+		{
+			p:    us,
+			s:    "1,234,567.5 mm",
+			want: 12345675 * Millimeter / 10,
+		},
+		{
+			p:    us,
+			s:    "1234.5mm",
+			want: 12345 * Millimeter / 10,
+		},
+		{
+			p:    us,
+			s:    "178cm",
+			want: 178 * Centimeter,
+		},
+		{
+			p:    eu,
+			s:    "1.234,5mm",
+			want: 12345 * Millimeter / 10,
+		},
+		{
+			p:    eu,
+			s:    "1,75 m",
+			want: 175 * Centimeter,
+		},
+		{
+			p:    fr,
+			s:    "1 234,5 mm",
+			want: 12345 * Millimeter / 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := tc.p.Parse(tc.s)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", tc.s, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Parse(%q): got %q, want %q", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestParserGroupingErrors(t *testing.T) {
+	us := Parser{GroupingSeparator: ','}
+	eu := Parser{DecimalSeparator: ',', GroupingSeparator: '.'}
+	testCases := []struct {
+		p Parser
+		s string
+	}{
+		{
+			// Mixed separators.
+			p: us,
+			s: "1.234,5mm",
+		},
+		{
+			p: eu,
+			s: "1,234.5mm",
+		},
+		{
+			p: us,
+			s: "12,34mm",
+		},
+		{
+			p: us,
+			s: "1234,567mm",
+		},
+		{
+			p: eu,
+			s: "1.5mm",
+		},
+		{
+			p: Parser{GroupingSeparator: '.'},
+			s: "1.234mm",
+		},
+	}
+
+	for _, tc := range testCases {
+		if _, err := tc.p.Parse(tc.s); err == nil {
+			t.Errorf("Parse(%q): expected an error", tc.s)
+		}
+	}
+}
+
+func TestParserStrict(t *testing.T) {
+	strict := Parser{Strict: true}
+	testCases := []struct {
+		s    string
+		want Length
+	}{
+		{s: "178cm", want: 178 * Centimeter},
+		{s: "1m78cm", want: 178 * Centimeter},
+		{s: "1m 78cm 5mm", want: 1785 * Millimeter},
+		{s: "2km 1m", want: 2001 * Meter},
+		{s: "5'10\"", want: 5*Foot + 10*Inch},
+		{s: "5ft 10in", want: 5*Foot + 10*Inch},
+	}
+
+	for _, tc := range testCases {
+		got, err := strict.Parse(tc.s)
+		if err != nil || got != tc.want {
+			t.Errorf("Parse(%q): got %q, %v, want %q", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func TestParserStrictErrors(t *testing.T) {
+	strict := Parser{Strict: true}
+	for _, s := range []string{
+		// Skipped unit.
+		"1m5mm",
+		"2km 3cm",
+		// Mixed systems.
+		"1m 10in",
+		// Decimeters are not part of the metric steps.
+		"1m 7dm",
+	} {
+		if got, err := strict.Parse(s); err == nil {
+			t.Errorf("Parse(%q): got %q, want error", s, got)
+		}
+		if _, err := (Parser{}).Parse(s); err != nil {
+			t.Errorf("Parse(%q) not strict: unexpected error: %v", s, err)
+		}
+	}
+}
+
+func TestParseCompoundOrder(t *testing.T) {
+	for _, p := range []Parser{{}, {Strict: true}} {
+		for _, s := range []string{
+			// Repeated unit.
+			"1m1m",
+			"1m 2m",
+			"5in 3in",
+			"5ft 1'",
+			// Ascending units.
+			"78cm 1m",
+			"10in 5ft",
+			"5in 5ft",
+		} {
+			_, err := p.Parse(s)
+			if !errors.Is(err, ErrSyntax) {
+				t.Errorf("Parse(%q), strict %t: got %v, want ErrSyntax", s, p.Strict, err)
+			}
+		}
+	}
+}
+
+func TestLocaleParser(t *testing.T) {
+	testCases := []struct {
+		locale string
+		s      string
+		want   Length
+	}{
+		{locale: "en", s: "1,234.5mm", want: 12345 * Millimeter / 10},
+		{locale: "en-US", s: "1.75 m", want: 175 * Centimeter},
+		{locale: "de", s: "1,75 m", want: 175 * Centimeter},
+		{locale: "de-DE", s: "1.234,5 mm", want: 12345 * Millimeter / 10},
+		{locale: "pt_BR", s: "1,75m", want: 175 * Centimeter},
+		{locale: "de-CH", s: "1’234.5 mm", want: 12345 * Millimeter / 10},
+		{locale: "fr", s: "1 234,5 mm", want: 12345 * Millimeter / 10},
+		{locale: "FR-ca", s: "1,75 m", want: 175 * Centimeter},
+		{locale: "fr", s: "3 1/2 in", want: 3*Inch + Inch/2},
+		{locale: "ru", s: "3 125/1000 in", want: 3*Inch + Inch/8},
+		{locale: "sv", s: "12 345 mm", want: 12345 * Millimeter},
+	}
+
+	for _, tc := range testCases {
+		p, ok := LocaleParser(tc.locale)
+		if !ok {
+			t.Errorf("LocaleParser(%q): unknown locale", tc.locale)
+			continue
+		}
+		if got, err := p.Parse(tc.s); err != nil || got != tc.want {
+			t.Errorf("LocaleParser(%q).Parse(%q): got %q, %v, want %q", tc.locale, tc.s, got, err, tc.want)
+		}
+	}
+
+	if _, ok := LocaleParser("tlh"); ok {
+		t.Errorf("LocaleParser(%q): got ok, want unknown locale", "tlh")
+	}
+	// Misread values are errors rather than silently wrong.
+	de, _ := LocaleParser("de")
+	if got, err := de.Parse("1.75 m"); err == nil {
+		t.Errorf("LocaleParser(%q).Parse(%q): got %q, want error", "de", "1.75 m", got)
+	}
+}
+
+func TestParserDefaultUnit(t *testing.T) {
+	cm := Parser{DefaultUnit: Centimeter}
+	testCases := []struct {
+		p        Parser
+		s        string
+		want     Length
+		wantUnit Length
+	}{
+		{p: cm, s: "178", want: 178 * Centimeter, wantUnit: Centimeter},
+		{p: cm, s: " 178.5 ", want: 1785 * Millimeter, wantUnit: Centimeter},
+		{p: cm, s: "1.78m", want: 178 * Centimeter, wantUnit: Meter},
+		{p: cm, s: "5'10", want: 5*Foot + 10*Inch, wantUnit: Inch},
+		{p: cm, s: "0", want: 0, wantUnit: 0},
+		{p: Parser{DefaultUnit: Inch}, s: "3 1/2", want: 3*Inch + Inch/2, wantUnit: Inch},
+		{p: Parser{DefaultUnit: Meter, DecimalSeparator: ','}, s: "1,78", want: 178 * Centimeter, wantUnit: Meter},
+	}
+
+	for _, tc := range testCases {
+		got, gotUnit, _, err := tc.p.parse(tc.s)
+		if err != nil || got != tc.want || gotUnit != tc.wantUnit {
+			t.Errorf("Parse(%q): got %q, %q, %v, want %q, %q", tc.s, got, gotUnit, err, tc.want, tc.wantUnit)
+		}
+	}
+
+	for _, s := range []string{"178 5", "1m 178 5"} {
+		if got, err := cm.Parse(s); err == nil {
+			t.Errorf("Parse(%q): got %q, want error", s, got)
+		}
+	}
+	if got, err := (Parser{}).Parse("178"); err == nil {
+		t.Errorf("Parse(%q) without default unit: got %q, want error", "178", got)
+	}
+}
+
+func TestAcceptedUnitSymbols(t *testing.T) {
+	symbols := AcceptedUnitSymbols()
+	accepted := make(map[string]bool)
+	for _, symbol := range symbols {
+		accepted[symbol] = true
+		if _, _, err := ParseLengthUnit("1" + symbol); err != nil {
+			t.Errorf("ParseLengthUnit(%q): unexpected error: %v", "1"+symbol, err)
+		}
+	}
+	for _, symbol := range []string{"nm", "μm", "um", "micron", "mm", "cm", "m", "km", "in", "ft", "'", `"`} {
+		if !accepted[symbol] {
+			t.Errorf("AcceptedUnitSymbols(): missing %q", symbol)
+		}
+	}
+	if symbols[0] != "nm" {
+		t.Errorf("AcceptedUnitSymbols(): got %q first, want \"nm\"", symbols[0])
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	for _, symbol := range AcceptedUnitSymbols() {
+		_, want, _ := ParseLengthUnit("1" + symbol)
+		if got, err := ParseUnit(symbol); err != nil || got != want {
+			t.Errorf("ParseUnit(%q): got %q, %v, want %q", symbol, got, err, want)
+		}
+	}
+	if got, err := ParseUnit(" cm "); err != nil || got != Centimeter {
+		t.Errorf("ParseUnit(%q): got %q, %v, want %q", " cm ", got, err, Centimeter)
+	}
+
+	testCases := []struct {
+		s          string
+		wantErr    error
+		wantToken  string
+		wantOffset int
+	}{
+		{s: "", wantErr: ErrMissingUnit, wantToken: "", wantOffset: 0},
+		{s: " cubit", wantErr: ErrUnknownUnit, wantToken: "cubit", wantOffset: 1},
+		{s: "1cm", wantErr: ErrUnknownUnit, wantToken: "1cm", wantOffset: 0},
+		{s: "CM", wantErr: ErrUnknownUnit, wantToken: "CM", wantOffset: 0},
+	}
+	for _, tc := range testCases {
+		_, err := ParseUnit(tc.s)
+		var perr *ParseError
+		if !errors.As(err, &perr) || !errors.Is(err, tc.wantErr) || perr.Token != tc.wantToken || perr.Offset != tc.wantOffset {
+			t.Errorf("ParseUnit(%q): got %v, want %v at %q, offset %d", tc.s, err, tc.wantErr, tc.wantToken, tc.wantOffset)
+		}
+	}
+}
+
+func TestNormalizeString(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want string
+	}{
+		{
+			s:    "178cm",
+			want: "178cm",
+		},
+		{
+			s:    "178.000cm",
+			want: "178cm",
+		},
+		{
+			s:    "0178.50 cm",
+			want: "178.5cm",
+		},
+		{
+			s:    "12.5 um",
+			want: "12.5μm",
+		},
+		{
+			s:    "12.5micron",
+			want: "12.5μm",
+		},
+		{
+			s:    "6'",
+			want: "6ft",
+		},
+		{
+			s:    `5'10"`,
+			want: "70in",
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := NormalizeString(tc.s)
+		if err != nil {
+			t.Errorf("NormalizeString(%q): unexpected error: %v", tc.s, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("NormalizeString(%q): got %q, want %q", tc.s, got, tc.want)
+		}
+	}
+
+	if _, err := NormalizeString("178 furlongs"); err == nil {
+		t.Errorf("NormalizeString(%q): expected an error", "178 furlongs")
+	}
+}
+
+func TestMustParseLength(t *testing.T) {
+	if got, want := MustParseLength("5'10\""), 5*Foot+10*Inch; got != want {
+		t.Errorf("MustParseLength(%q): got %q, want %q", "5'10\"", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParseLength(%q): got no panic", "tall")
+		}
+	}()
+	MustParseLength("tall")
+}
+
+func TestL(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want Length
+	}{
+		{s: "1.234567mm", want: 1234567 * Nanometer},
+		{s: "178cm", want: 178 * Centimeter},
+		{s: "1.78m", want: 178 * Centimeter},
+		{s: "12.5μm", want: 12500 * Nanometer},
+		{s: "5'10\"", want: 5*Foot + 10*Inch},
+		{s: "0", want: 0},
+	}
+
+	for _, tc := range testCases {
+		if got := L(tc.s); got != tc.want {
+			t.Errorf("L(%q): got %q, want %q", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestLPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("L(%q): got no panic", "1.78")
+		}
+	}()
+	L("1.78")
+}
+
+func TestSumLabeled(t *testing.T) {
+	r := strings.NewReader("shelf: 1.2m\n\nbracket: 30cm\n  bracket : 30cm  \r\nscrew:5mm\n")
+	total, totals, err := SumLabeled(r)
+	if err != nil {
+		t.Fatalf("SumLabeled(): unexpected error: %v", err)
+	}
+	if want := 1805 * Millimeter; total != want {
+		t.Errorf("SumLabeled(): got total %q, want %q", total, want)
+	}
+	want := map[string]Length{
+		"shelf":   120 * Centimeter,
+		"bracket": 60 * Centimeter,
+		"screw":   5 * Millimeter,
+	}
+	if len(totals) != len(want) {
+		t.Errorf("SumLabeled(): got %v, want %v", totals, want)
+	}
+	for label, l := range want {
+		if totals[label] != l {
+			t.Errorf("SumLabeled()[%q]: got %q, want %q", label, totals[label], l)
+		}
+	}
+}
+
+func TestSumLabeledErrors(t *testing.T) {
+	testCases := []struct {
+		s        string
+		wantLine string
+	}{
+		{s: "shelf: 1.2m\nbracket 30cm\n", wantLine: "line 2: "},
+		{s: "shelf: 1.2m\n\n: 30cm\n", wantLine: "line 3: "},
+		{s: "shelf: 1.2m\nbracket: 30 cubits\n", wantLine: "line 2: "},
+		{s: "shelf: 10000000km\nshelf: 10000000km\n", wantLine: "line 2: "},
+	}
+
+	for _, tc := range testCases {
+		_, _, err := SumLabeled(strings.NewReader(tc.s))
+		if err == nil || !strings.HasPrefix(err.Error(), tc.wantLine) {
+			t.Errorf("SumLabeled(%q): got error %v, want prefix %q", tc.s, err, tc.wantLine)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package lengths
+
+// A Quantity is a physical quantity represented as an integer count of
+// nano base units, such as a Length being a count of nanometers. It allows
+// formatting utilities to work across quantity types (length, mass, time...).
+type Quantity interface {
+	// Nano returns the quantity as a count of nano base units.
+	Nano() uint64
+	// Symbol returns the symbol of the quantity's base unit.
+	Symbol() string
+}
+
+// Nano returns the length as a nanometer count.
+func (l Length) Nano() uint64 {
+	return uint64(l)
+}
+
+// Symbol returns "m", the symbol of the meter which is the base unit of
+// lengths.
+func (Length) Symbol() string {
+	return "m"
+}
+
+// FormatQuantity returns the quantity formatted in its base unit, with as
+// many decimals as needed:
+//
+//	fmt.Print(lengths.FormatQuantity(178 * lengths.Centimeter)) // prints 1.78m
+func FormatQuantity(q Quantity) string {
+	n := q.Nano()
+	return formatFloat(float64(n/1e9)+float64(n%1e9)/1e9) + q.Symbol()
+}
@@ -0,0 +1,50 @@
+package lengths
+
+import "testing"
+
+var _ Quantity = Length(0)
+
+// mass is a Quantity other than Length, counted in nanograms.
+type mass uint64
+
+func (m mass) Nano() uint64 {
+	return uint64(m)
+}
+
+func (mass) Symbol() string {
+	return "g"
+}
+
+func TestFormatQuantity(t *testing.T) {
+	testCases := []struct {
+		q    Quantity
+		want string
+	}{
+		{
+			q:    Length(0),
+			want: "0m",
+		},
+		{
+			q:    178 * Centimeter,
+			want: "1.78m",
+		},
+		{
+			q:    1234 * Nanometer,
+			want: "0.000001234m",
+		},
+		{
+			q:    5 * Kilometer,
+			want: "5000m",
+		},
+		{
+			q:    mass(65e12),
+			want: "65000g",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := FormatQuantity(tc.q); got != tc.want {
+			t.Errorf("FormatQuantity(): got %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package lengths
+
+// A Range represents the interval of lengths between Min and Max, both
+// included, such as a clothing size band. Ranges are expected to have Min no
+// longer than Max.
+type Range struct {
+	Min Length
+	Max Length
+}
+
+// Contains reports whether l lies within the range.
+func (r Range) Contains(l Length) bool {
+	return r.Min <= l && l <= r.Max
+}
+
+// Overlaps reports whether the range and o have at least one length in
+// common.
+func (r Range) Overlaps(o Range) bool {
+	return r.Min <= o.Max && o.Min <= r.Max && r.Min <= r.Max && o.Min <= o.Max
+}
+
+// Width returns the distance between the bounds of the range, or zero if the
+// range is inverted.
+func (r Range) Width() Length {
+	if r.Max < r.Min {
+		return 0
+	}
+	return r.Max - r.Min
+}
+
+// String returns the range in the format accepted by ParseRange, such as
+// "150cm-200cm". A range with MaxLength as maximum is formatted as open-ended,
+// such as "150cm-".
+func (r Range) String() string {
+	if r.Max == MaxLength {
+		return r.Min.String() + "-"
+	}
+	return r.Min.String() + "-" + r.Max.String()
+}
+
+// Mid returns the length halfway between the bounds of the range, floored to
+// the closest nanometer. The bounds of an inverted range are swapped.
+func (r Range) Mid() Length {
+	lo, hi := r.Min, r.Max
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	return lo + (hi-lo)/2
+}
+
+// Subdivide splits the range into n contiguous sub-ranges of equal width,
+// each starting where the previous one ends. The last sub-range absorbs the
+// remainder of the division so that the sub-ranges cover the range exactly.
+// It returns nil if n is not positive or if the range is inverted.
+func (r Range) Subdivide(n int) []Range {
+	if n <= 0 || r.Max < r.Min {
+		return nil
+	}
+	step := (r.Max - r.Min) / Length(n)
+	subs := make([]Range, n)
+	min := r.Min
+	for i := range subs {
+		subs[i] = Range{Min: min, Max: min + step}
+		min += step
+	}
+	subs[n-1].Max = r.Max
+	return subs
+}
+
+// InAny returns the index of the first of the ranges containing the length,
+// such as the size bucket a measurement falls into, and whether any range
+// contains it. It returns -1 and false if no range contains the length.
+func (l Length) InAny(ranges ...Range) (int, bool) {
+	for i, r := range ranges {
+		if r.Contains(l) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Sum returns the sum of the bounds of the range and whether it could be
+// computed without overflowing.
+func (r Range) Sum() (Length, bool) {
+	return Sum(r.Min, r.Max)
+}
+
+// NiceStep returns a round step, 1, 2 or 5 times a power of ten nanometers,
+// splitting span into about approxCount intervals, such as for the ticks of
+// a plot axis. The step is shortened if it would overflow. It returns zero
+// if approxCount is not positive.
+func NiceStep(span Length, approxCount int) Length {
+	if approxCount <= 0 {
+		return 0
+	}
+	raw := span / Length(approxCount)
+	if raw == 0 {
+		return Nanometer
+	}
+	mag := Nanometer
+	for mag <= raw/10 {
+		mag *= 10
+	}
+	var k Length
+	switch f := float64(raw) / float64(mag); {
+	case f < 1.5:
+		k = 1
+	case f < 3:
+		k = 2
+	case f < 7:
+		k = 5
+	default:
+		k = 10
+	}
+	// Fall back to a shorter step if the chosen one overflows.
+	for mag > MaxLength/k {
+		k /= 2
+	}
+	return k * mag
+}
+
+// Ticks returns evenly spaced lengths covering the range, about approxCount
+// intervals apart as chosen by NiceStep, such as for the ticks of a plot
+// axis. The ticks start at the multiple of the step at or below Min and end
+// at the multiple of the step at or above Max, or at the last representable
+// multiple. It returns nil if approxCount is not positive or if the range is
+// inverted.
+func (r Range) Ticks(approxCount int) Lengths {
+	if approxCount <= 0 || r.Max < r.Min {
+		return nil
+	}
+	step := NiceStep(r.Width(), approxCount)
+	var ticks Lengths
+	for tick := r.Min / step * step; ; tick += step {
+		ticks = append(ticks, tick)
+		if tick >= r.Max || tick > MaxLength-step {
+			return ticks
+		}
+	}
+}
+
+// Clamp returns l limited to the range, as done by Length.Clamp. The bounds
+// of an inverted range are swapped.
+func (r Range) Clamp(l Length) Length {
+	if r.Max < r.Min {
+		return l.Clamp(r.Max, r.Min)
+	}
+	return l.Clamp(r.Min, r.Max)
+}
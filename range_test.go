@@ -0,0 +1,509 @@
+package lengths
+
+import "testing"
+
+func TestRangeContains(t *testing.T) {
+	r := Range{Min: 150 * Centimeter, Max: 200 * Centimeter}
+	testCases := []struct {
+		l    Length
+		want bool
+	}{
+		{
+			l:    149 * Centimeter,
+			want: false,
+		},
+		{
+			l:    150 * Centimeter,
+			want: true,
+		},
+		{
+			l:    178 * Centimeter,
+			want: true,
+		},
+		{
+			l:    200 * Centimeter,
+			want: true,
+		},
+		{
+			l:    200*Centimeter + Nanometer,
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := r.Contains(tc.l); got != tc.want {
+			t.Errorf("Contains(%q): got %t, want %t", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestRangeOverlaps(t *testing.T) {
+	r := Range{Min: 150 * Centimeter, Max: 200 * Centimeter}
+	testCases := []struct {
+		o    Range
+		want bool
+	}{
+		{
+			o:    Range{Min: 100 * Centimeter, Max: 149 * Centimeter},
+			want: false,
+		},
+		{
+			o:    Range{Min: 100 * Centimeter, Max: 150 * Centimeter},
+			want: true,
+		},
+		{
+			o:    Range{Min: 160 * Centimeter, Max: 170 * Centimeter},
+			want: true,
+		},
+		{
+			o:    Range{Min: 100 * Centimeter, Max: 300 * Centimeter},
+			want: true,
+		},
+		{
+			o:    Range{Min: 190 * Centimeter, Max: 250 * Centimeter},
+			want: true,
+		},
+		{
+			o:    Range{Min: 201 * Centimeter, Max: 250 * Centimeter},
+			want: false,
+		},
+		{
+			o:    Range{Min: 250 * Centimeter, Max: 100 * Centimeter},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := r.Overlaps(tc.o); got != tc.want {
+			t.Errorf("Overlaps(%v): got %t, want %t", tc.o, got, tc.want)
+		}
+		if got := tc.o.Overlaps(r); got != tc.want {
+			t.Errorf("Overlaps(%v): got %t, want %t", r, got, tc.want)
+		}
+	}
+}
+
+func TestRangeWidthAndString(t *testing.T) {
+	testCases := []struct {
+		r          Range
+		wantWidth  Length
+		wantString string
+	}{
+		{
+			r:          Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			wantWidth:  50 * Centimeter,
+			wantString: "1.5m-2m",
+		},
+		{
+			r:          Range{Min: 178 * Centimeter, Max: 178 * Centimeter},
+			wantWidth:  0,
+			wantString: "1.78m-1.78m",
+		},
+		{
+			r:          Range{Min: 0, Max: 90 * Centimeter},
+			wantWidth:  90 * Centimeter,
+			wantString: "0-90cm",
+		},
+		{
+			r:          Range{Min: 150 * Centimeter, Max: MaxLength},
+			wantWidth:  MaxLength - 150*Centimeter,
+			wantString: "1.5m-",
+		},
+		{
+			r:          Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			wantWidth:  0,
+			wantString: "2m-1.5m",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.r.Width(); got != tc.wantWidth {
+			t.Errorf("Width(): got %q, want %q", got, tc.wantWidth)
+		}
+		if got := tc.r.String(); got != tc.wantString {
+			t.Errorf("String(): got %q, want %q", got, tc.wantString)
+		}
+	}
+}
+
+func TestRangeMid(t *testing.T) {
+	testCases := []struct {
+		r    Range
+		want Length
+	}{
+		{
+			r:    Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			want: 175 * Centimeter,
+		},
+		{
+			r:    Range{Min: 178 * Centimeter, Max: 178 * Centimeter},
+			want: 178 * Centimeter,
+		},
+		{
+			r:    Range{Min: 0, Max: 3 * Nanometer},
+			want: 1 * Nanometer,
+		},
+		{
+			r:    Range{Min: MaxLength - 2, Max: MaxLength},
+			want: MaxLength - 1,
+		},
+		{
+			r:    Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			want: 175 * Centimeter,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.r.Mid(); got != tc.want {
+			t.Errorf("Mid(): got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestRangeSubdivide(t *testing.T) {
+	testCases := []struct {
+		r    Range
+		n    int
+		want []Range
+	}{
+		{
+			r:    Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			n:    0,
+			want: nil,
+		},
+		{
+			r:    Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			n:    2,
+			want: nil,
+		},
+		{
+			r: Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			n: 1,
+			want: []Range{
+				{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			},
+		},
+		{
+			r: Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			n: 5,
+			want: []Range{
+				{Min: 150 * Centimeter, Max: 160 * Centimeter},
+				{Min: 160 * Centimeter, Max: 170 * Centimeter},
+				{Min: 170 * Centimeter, Max: 180 * Centimeter},
+				{Min: 180 * Centimeter, Max: 190 * Centimeter},
+				{Min: 190 * Centimeter, Max: 200 * Centimeter},
+			},
+		},
+		{
+			r: Range{Min: 0, Max: 11 * Nanometer},
+			n: 3,
+			want: []Range{
+				{Min: 0, Max: 3 * Nanometer},
+				{Min: 3 * Nanometer, Max: 6 * Nanometer},
+				{Min: 6 * Nanometer, Max: 11 * Nanometer},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.r.Subdivide(tc.n)
+		if len(got) != len(tc.want) {
+			t.Errorf("Subdivide(%d): got %v, want %v", tc.n, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("Subdivide(%d): got %v, want %v", tc.n, got, tc.want)
+				break
+			}
+		}
+	}
+
+	// The sub-ranges tile the parent exactly whatever the remainder.
+	r := Range{Min: 123 * Micrometer, Max: 1234567 * Micrometer}
+	for n := 1; n <= 100; n++ {
+		subs := r.Subdivide(n)
+		if subs[0].Min != r.Min || subs[n-1].Max != r.Max {
+			t.Errorf("Subdivide(%d): got %v, want bounds of %v", n, subs, r)
+		}
+		for i := 1; i < n; i++ {
+			if subs[i].Min != subs[i-1].Max {
+				t.Errorf("Subdivide(%d): sub-ranges %d and %d are not contiguous", n, i-1, i)
+			}
+		}
+	}
+}
+
+func TestInAny(t *testing.T) {
+	disjoint := []Range{
+		{Min: 80 * Centimeter, Max: 90 * Centimeter},
+		{Min: 95 * Centimeter, Max: 100 * Centimeter},
+		{Min: 100*Centimeter + Nanometer, Max: 110 * Centimeter},
+	}
+	overlapping := []Range{
+		{Min: 80 * Centimeter, Max: 100 * Centimeter},
+		{Min: 95 * Centimeter, Max: 110 * Centimeter},
+	}
+	testCases := []struct {
+		l         Length
+		ranges    []Range
+		wantIndex int
+		wantOK    bool
+	}{
+		{
+			l:         85 * Centimeter,
+			ranges:    disjoint,
+			wantIndex: 0,
+			wantOK:    true,
+		},
+		{
+			l:         100 * Centimeter,
+			ranges:    disjoint,
+			wantIndex: 1,
+			wantOK:    true,
+		},
+		{
+			l:         105 * Centimeter,
+			ranges:    disjoint,
+			wantIndex: 2,
+			wantOK:    true,
+		},
+		{
+			l:         92 * Centimeter,
+			ranges:    disjoint,
+			wantIndex: -1,
+			wantOK:    false,
+		},
+		{
+			l:         120 * Centimeter,
+			ranges:    disjoint,
+			wantIndex: -1,
+			wantOK:    false,
+		},
+		{
+			l:         97 * Centimeter,
+			ranges:    overlapping,
+			wantIndex: 0,
+			wantOK:    true,
+		},
+		{
+			l:         105 * Centimeter,
+			ranges:    overlapping,
+			wantIndex: 1,
+			wantOK:    true,
+		},
+		{
+			l:         105 * Centimeter,
+			ranges:    nil,
+			wantIndex: -1,
+			wantOK:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		gotIndex, gotOK := tc.l.InAny(tc.ranges...)
+		if gotIndex != tc.wantIndex || gotOK != tc.wantOK {
+			t.Errorf(
+				"InAny(%q): got %d, %t, want %d, %t",
+				tc.l,
+				gotIndex,
+				gotOK,
+				tc.wantIndex,
+				tc.wantOK,
+			)
+		}
+	}
+}
+
+func TestRangeSum(t *testing.T) {
+	testCases := []struct {
+		r      Range
+		want   Length
+		wantOK bool
+	}{
+		{
+			r:      Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			want:   350 * Centimeter,
+			wantOK: true,
+		},
+		{
+			r:      Range{Min: 0, Max: MaxLength},
+			want:   MaxLength,
+			wantOK: true,
+		},
+		{
+			r:      Range{Min: MaxLength - 1, Max: MaxLength},
+			want:   0,
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotOK := tc.r.Sum()
+		if got != tc.want || gotOK != tc.wantOK {
+			t.Errorf("Sum(%v): got %q, %t, want %q, %t", tc.r, got, gotOK, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestNiceStep(t *testing.T) {
+	testCases := []struct {
+		span        Length
+		approxCount int
+		want        Length
+	}{
+		{
+			span:        1 * Meter,
+			approxCount: 0,
+			want:        0,
+		},
+		{
+			span:        0,
+			approxCount: 5,
+			want:        Nanometer,
+		},
+		{
+			span:        1 * Meter,
+			approxCount: 10,
+			want:        10 * Centimeter,
+		},
+		{
+			span:        1 * Meter,
+			approxCount: 5,
+			want:        20 * Centimeter,
+		},
+		{
+			span:        1 * Meter,
+			approxCount: 3,
+			want:        50 * Centimeter,
+		},
+		{
+			span:        50 * Centimeter,
+			approxCount: 6,
+			want:        10 * Centimeter,
+		},
+		{
+			span:        MaxLength,
+			approxCount: 1,
+			want:        1e19 * Nanometer,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := NiceStep(tc.span, tc.approxCount); got != tc.want {
+			t.Errorf("NiceStep(%q, %d): got %q, want %q", tc.span, tc.approxCount, got, tc.want)
+		}
+	}
+}
+
+func TestRangeTicks(t *testing.T) {
+	testCases := []struct {
+		r           Range
+		approxCount int
+		want        Lengths
+	}{
+		{
+			r:           Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			approxCount: 0,
+			want:        nil,
+		},
+		{
+			r:           Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			approxCount: 5,
+			want:        nil,
+		},
+		{
+			r:           Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			approxCount: 5,
+			want: Lengths{
+				150 * Centimeter,
+				160 * Centimeter,
+				170 * Centimeter,
+				180 * Centimeter,
+				190 * Centimeter,
+				200 * Centimeter,
+			},
+		},
+		{
+			r:           Range{Min: 153 * Centimeter, Max: 197 * Centimeter},
+			approxCount: 2,
+			want: Lengths{
+				140 * Centimeter,
+				160 * Centimeter,
+				180 * Centimeter,
+				200 * Centimeter,
+			},
+		},
+		{
+			r:           Range{Min: 178 * Centimeter, Max: 178 * Centimeter},
+			approxCount: 5,
+			want:        Lengths{178 * Centimeter},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.r.Ticks(tc.approxCount)
+		if len(got) != len(tc.want) {
+			t.Errorf("Ticks(%d): got %v, want %v", tc.approxCount, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("Ticks(%d): got %v, want %v", tc.approxCount, got, tc.want)
+				break
+			}
+		}
+	}
+
+	// The ticks bracket the range even close to MaxLength.
+	r := Range{Min: MaxLength - 15, Max: MaxLength}
+	ticks := r.Ticks(3)
+	if ticks[0] > r.Min || ticks[len(ticks)-1] < MaxLength-5 {
+		t.Errorf("Ticks(3): got %v, want ticks bracketing %v", ticks, r)
+	}
+}
+
+func TestRangeClamp(t *testing.T) {
+	testCases := []struct {
+		r    Range
+		l    Length
+		want Length
+	}{
+		{
+			r:    Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			l:    100 * Centimeter,
+			want: 150 * Centimeter,
+		},
+		{
+			r:    Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			l:    178 * Centimeter,
+			want: 178 * Centimeter,
+		},
+		{
+			r:    Range{Min: 150 * Centimeter, Max: 200 * Centimeter},
+			l:    250 * Centimeter,
+			want: 200 * Centimeter,
+		},
+		{
+			r:    Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			l:    100 * Centimeter,
+			want: 150 * Centimeter,
+		},
+		{
+			r:    Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			l:    178 * Centimeter,
+			want: 178 * Centimeter,
+		},
+		{
+			r:    Range{Min: 200 * Centimeter, Max: 150 * Centimeter},
+			l:    250 * Centimeter,
+			want: 200 * Centimeter,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.r.Clamp(tc.l); got != tc.want {
+			t.Errorf("Clamp(%v, %q): got %q, want %q", tc.r, tc.l, got, tc.want)
+		}
+	}
+}
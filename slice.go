@@ -0,0 +1,171 @@
+package lengths
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// Lengths attaches methods to a slice of lengths, such as a series of
+// measurements.
+type Lengths []Length
+
+// ClampAll returns a new slice where each length is clamped to [lo, hi] as
+// done by Clamp. The receiver is not modified.
+func (ls Lengths) ClampAll(lo, hi Length) Lengths {
+	clamped := make(Lengths, len(ls))
+	for i, l := range ls {
+		clamped[i] = l.Clamp(lo, hi)
+	}
+	return clamped
+}
+
+// CommonUnit returns the unit String would use for the longest length of the
+// slice, so that all lengths can be displayed in the same unit with
+// FormatUnit. This avoids displays such as 99.9cm next to 1.001m. It returns
+// Nanometer if the slice is empty or only holds zero lengths.
+func (ls Lengths) CommonUnit() Length {
+	var longest Length
+	for _, l := range ls {
+		if l > longest {
+			longest = l
+		}
+	}
+	return longest.autoUnit()
+}
+
+// Deltas returns the signed nanometer differences between each length and
+// the previous one, so it holds one element less than the slice. Differences
+// beyond the int64 range are limited to it.
+func (ls Lengths) Deltas() []int64 {
+	if len(ls) < 2 {
+		return nil
+	}
+	deltas := make([]int64, len(ls)-1)
+	for i := range deltas {
+		deltas[i] = diff(ls[i+1], ls[i])
+	}
+	return deltas
+}
+
+// HarmonicMean returns the harmonic mean of the lengths, rounded to the
+// nearest nanometer, as suited to averaging rate-like measurements. It returns
+// zero if the slice is empty or holds a zero length, whose reciprocal is
+// undefined.
+func (ls Lengths) HarmonicMean() Length {
+	if len(ls) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, l := range ls {
+		if l == 0 {
+			return 0
+		}
+		sum += 1 / float64(l)
+	}
+	return saturate(float64(len(ls)) / sum)
+}
+
+// GeometricMean returns the geometric mean of the lengths, rounded to the
+// nearest nanometer, as suited to averaging lengths obtained by scaling a base
+// length. It is computed from the mean of logarithms so that the product
+// cannot overflow. It returns zero if the slice is empty or holds a zero
+// length, which zeroes the product.
+func (ls Lengths) GeometricMean() Length {
+	if len(ls) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, l := range ls {
+		if l == 0 {
+			return 0
+		}
+		sum += math.Log(float64(l))
+	}
+	return saturate(math.Exp(sum / float64(len(ls))))
+}
+
+// Mode returns the most common length of the slice and its number of
+// occurrences. Ties are broken toward the shorter length. It returns zero and
+// a count of 0 if the slice is empty.
+func (ls Lengths) Mode() (Length, int) {
+	counts := make(map[Length]int, len(ls))
+	var mode Length
+	var count int
+	for _, l := range ls {
+		counts[l]++
+		if c := counts[l]; c > count || c == count && l < mode {
+			mode, count = l, c
+		}
+	}
+	return mode, count
+}
+
+// TrimmedMean returns the mean of the lengths, rounded to the closest
+// nanometer, after discarding the given fraction of the shortest and of the
+// longest lengths, so that outliers do not skew it. The number of lengths
+// discarded at each end is rounded down. It returns zero if the slice is empty
+// or if fraction is not in [0, 0.5). The receiver is not modified.
+func (ls Lengths) TrimmedMean(fraction float64) Length {
+	if len(ls) == 0 || !(fraction >= 0 && fraction < 0.5) {
+		return 0
+	}
+	sorted := append(Lengths(nil), ls...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	k := int(fraction * float64(len(sorted)))
+	kept := sorted[k : len(sorted)-k]
+
+	var sum sum128
+	for _, l := range kept {
+		sum.add(l)
+	}
+	return sum.mean(len(kept))
+}
+
+// A sum128 is a sum of lengths accumulated on 128 bits so that it cannot
+// overflow.
+type sum128 struct {
+	hi, lo uint64
+}
+
+func (s *sum128) add(l Length) {
+	var carry uint64
+	s.lo, carry = bits.Add64(s.lo, uint64(l), 0)
+	s.hi += carry
+}
+
+func (s *sum128) sub(l Length) {
+	var borrow uint64
+	s.lo, borrow = bits.Sub64(s.lo, uint64(l), 0)
+	s.hi -= borrow
+}
+
+// mean returns the sum divided by n > 0, rounded to the closest nanometer. It
+// must not exceed n times MaxLength.
+func (s sum128) mean(n int) Length {
+	lo, carry := bits.Add64(s.lo, uint64(n)/2, 0)
+	q, _ := bits.Div64(s.hi+carry, lo, uint64(n))
+	return Length(q)
+}
+
+// MovingAverage returns the mean of each window of window consecutive
+// lengths, rounded to the closest nanometer, such as for smoothing a series
+// of measurements. The result holds len(ls)-window+1 means, the first one
+// being the mean of ls[:window]. It returns nil if window is not in
+// [1, len(ls)].
+func (ls Lengths) MovingAverage(window int) Lengths {
+	if window < 1 || window > len(ls) {
+		return nil
+	}
+	var sum sum128
+	for _, l := range ls[:window-1] {
+		sum.add(l)
+	}
+	means := make(Lengths, len(ls)-window+1)
+	for i := range means {
+		sum.add(ls[i+window-1])
+		means[i] = sum.mean(window)
+		sum.sub(ls[i])
+	}
+	return means
+}
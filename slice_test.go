@@ -0,0 +1,368 @@
+package lengths
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClampAll(t *testing.T) {
+	ls := Lengths{10 * Centimeter, 178 * Centimeter, 3 * Meter}
+	want := Lengths{50 * Centimeter, 178 * Centimeter, 2 * Meter}
+
+	got := ls.ClampAll(50*Centimeter, 2*Meter)
+	if len(got) != len(want) {
+		t.Fatalf("ClampAll(): got %d lengths, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ClampAll()[%d]: got %q, want %q", i, got[i], want[i])
+		}
+	}
+	if ls[0] != 10*Centimeter || ls[2] != 3*Meter {
+		t.Errorf("ClampAll(): input modified to %v", ls)
+	}
+}
+
+func TestCommonUnit(t *testing.T) {
+	testCases := []struct {
+		ls   Lengths
+		want Length
+	}{
+		{
+			ls:   nil,
+			want: Nanometer,
+		},
+		{
+			ls:   Lengths{0, 0},
+			want: Nanometer,
+		},
+		{
+			ls:   Lengths{150 * Centimeter, 178 * Centimeter},
+			want: Meter,
+		},
+		{
+			ls:   Lengths{999 * Millimeter, 1001 * Millimeter},
+			want: Meter,
+		},
+		{
+			ls:   Lengths{5 * Millimeter, 99 * Centimeter},
+			want: Centimeter,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.ls.CommonUnit(); got != tc.want {
+			t.Errorf("CommonUnit(): got %q, want %q", got, tc.want)
+		}
+	}
+
+	ls := Lengths{999 * Millimeter, 1001 * Millimeter}
+	unit := ls.CommonUnit()
+	want := []string{"0.999m", "1.001m"}
+	for i, l := range ls {
+		if got := l.FormatUnit(unit); got != want[i] {
+			t.Errorf("FormatUnit(): got %q, want %q", got, want[i])
+		}
+	}
+}
+
+func TestDeltas(t *testing.T) {
+	testCases := []struct {
+		ls   Lengths
+		want []int64
+	}{
+		{
+			ls:   nil,
+			want: nil,
+		},
+		{
+			ls:   Lengths{178 * Centimeter},
+			want: nil,
+		},
+		{
+			ls:   Lengths{100 * Centimeter, 110 * Centimeter, 125 * Centimeter},
+			want: []int64{int64(10 * Centimeter), int64(15 * Centimeter)},
+		},
+		{
+			ls:   Lengths{125 * Centimeter, 110 * Centimeter, 110 * Centimeter},
+			want: []int64{-int64(15 * Centimeter), 0},
+		},
+		{
+			ls:   Lengths{0, MaxLength, 0},
+			want: []int64{math.MaxInt64, math.MinInt64},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.ls.Deltas()
+		if len(got) != len(tc.want) {
+			t.Errorf("Deltas(): got %v, want %v", got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("Deltas(): got %v, want %v", got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestHarmonicMean(t *testing.T) {
+	testCases := []struct {
+		ls   Lengths
+		want Length
+	}{
+		{
+			ls:   nil,
+			want: 0,
+		},
+		{
+			ls:   Lengths{75 * Centimeter},
+			want: 75 * Centimeter,
+		},
+		{
+			// 2 / (1/1 + 1/3) = 1.5
+			ls:   Lengths{1 * Meter, 3 * Meter},
+			want: 1500 * Millimeter,
+		},
+		{
+			// 3 / (3/120 + 2/120 + 1/120) = 60
+			ls:   Lengths{40 * Centimeter, 60 * Centimeter, 120 * Centimeter},
+			want: 60 * Centimeter,
+		},
+		{
+			ls:   Lengths{1 * Meter, 0, 3 * Meter},
+			want: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.ls.HarmonicMean(); got != tc.want {
+			t.Errorf("HarmonicMean(%v): got %q, want %q", tc.ls, got, tc.want)
+		}
+	}
+}
+
+func TestGeometricMean(t *testing.T) {
+	testCases := []struct {
+		ls   Lengths
+		want Length
+	}{
+		{
+			ls:   nil,
+			want: 0,
+		},
+		{
+			ls:   Lengths{75 * Centimeter},
+			want: 75 * Centimeter,
+		},
+		{
+			// sqrt(1 * 4) = 2
+			ls:   Lengths{1 * Meter, 4 * Meter},
+			want: 2 * Meter,
+		},
+		{
+			// cbrt(10 * 20 * 50) = cbrt(10000) = 21.5443469...
+			ls:   Lengths{10 * Centimeter, 20 * Centimeter, 50 * Centimeter},
+			want: 215443469 * Nanometer,
+		},
+		{
+			// The product overflows but the mean does not.
+			ls:   Lengths{MaxLength / 4, MaxLength / 4},
+			want: MaxLength / 4,
+		},
+		{
+			ls:   Lengths{1 * Meter, 0, 4 * Meter},
+			want: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		// Logarithms lose a few ulps of relative precision.
+		got := tc.ls.GeometricMean()
+		tolerance := 1 + tc.want/1e12
+		if got+tolerance < tc.want || got > tc.want+tolerance {
+			t.Errorf("GeometricMean(%v): got %q, want %q", tc.ls, got, tc.want)
+		}
+	}
+}
+
+func TestMode(t *testing.T) {
+	testCases := []struct {
+		ls        Lengths
+		want      Length
+		wantCount int
+	}{
+		{
+			ls:        nil,
+			want:      0,
+			wantCount: 0,
+		},
+		{
+			ls:        Lengths{42 * Centimeter},
+			want:      42 * Centimeter,
+			wantCount: 1,
+		},
+		{
+			ls:        Lengths{40 * Centimeter, 42 * Centimeter, 44 * Centimeter, 42 * Centimeter, 42 * Centimeter, 40 * Centimeter},
+			want:      42 * Centimeter,
+			wantCount: 3,
+		},
+		{
+			ls:        Lengths{44 * Centimeter, 42 * Centimeter, 44 * Centimeter, 42 * Centimeter},
+			want:      42 * Centimeter,
+			wantCount: 2,
+		},
+		{
+			ls:        Lengths{3 * Meter, 2 * Meter, 1 * Meter},
+			want:      1 * Meter,
+			wantCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, gotCount := tc.ls.Mode()
+		if got != tc.want || gotCount != tc.wantCount {
+			t.Errorf("Mode(%v): got %q, %d, want %q, %d", tc.ls, got, gotCount, tc.want, tc.wantCount)
+		}
+	}
+}
+
+func TestTrimmedMean(t *testing.T) {
+	// Heights with a sensor glitch at each end.
+	heights := Lengths{
+		172 * Centimeter,
+		3 * Centimeter,
+		175 * Centimeter,
+		178 * Centimeter,
+		181 * Centimeter,
+		950 * Centimeter,
+		174 * Centimeter,
+		176 * Centimeter,
+		179 * Centimeter,
+		177 * Centimeter,
+	}
+	testCases := []struct {
+		ls       Lengths
+		fraction float64
+		want     Length
+	}{
+		{
+			ls:       nil,
+			fraction: 0.1,
+			want:     0,
+		},
+		{
+			// (1412 + 3 + 950) / 10
+			ls:       heights,
+			fraction: 0,
+			want:     2365 * Millimeter,
+		},
+		{
+			// 1412 / 8
+			ls:       heights,
+			fraction: 0.1,
+			want:     1765 * Millimeter,
+		},
+		{
+			// Rounded down to discarding 1 length at each end.
+			ls:       heights,
+			fraction: 0.15,
+			want:     1765 * Millimeter,
+		},
+		{
+			// (175 + 176 + 177 + 178) / 4
+			ls:       heights,
+			fraction: 0.3,
+			want:     1765 * Millimeter,
+		},
+		{
+			ls:       Lengths{MaxLength, MaxLength - 2},
+			fraction: 0,
+			want:     MaxLength - 1,
+		},
+		{
+			ls:       heights,
+			fraction: 0.5,
+			want:     0,
+		},
+		{
+			ls:       heights,
+			fraction: -0.1,
+			want:     0,
+		},
+		{
+			ls:       heights,
+			fraction: math.NaN(),
+			want:     0,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.ls.TrimmedMean(tc.fraction); got != tc.want {
+			t.Errorf("TrimmedMean(%g): got %q, want %q", tc.fraction, got, tc.want)
+		}
+	}
+	if heights[1] != 3*Centimeter {
+		t.Errorf("TrimmedMean(): input modified to %v", heights)
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	series := Lengths{10 * Centimeter, 20 * Centimeter, 60 * Centimeter, 30 * Centimeter, 35 * Centimeter}
+	testCases := []struct {
+		ls     Lengths
+		window int
+		want   Lengths
+	}{
+		{
+			ls:     series,
+			window: 1,
+			want:   series,
+		},
+		{
+			ls:     series,
+			window: 2,
+			want:   Lengths{15 * Centimeter, 40 * Centimeter, 45 * Centimeter, 325 * Millimeter},
+		},
+		{
+			ls:     series,
+			window: 3,
+			want:   Lengths{30 * Centimeter, 366666667 * Nanometer, 416666667 * Nanometer},
+		},
+		{
+			ls:     series,
+			window: 5,
+			want:   Lengths{31 * Centimeter},
+		},
+		{
+			ls:     Lengths{MaxLength, MaxLength, MaxLength - 4},
+			window: 2,
+			want:   Lengths{MaxLength, MaxLength - 2},
+		},
+		{
+			ls:     series,
+			window: 0,
+			want:   nil,
+		},
+		{
+			ls:     series,
+			window: 6,
+			want:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		got := tc.ls.MovingAverage(tc.window)
+		if len(got) != len(tc.want) {
+			t.Errorf("MovingAverage(%d): got %v, want %v", tc.window, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("MovingAverage(%d)[%d]: got %q, want %q", tc.window, i, got[i], tc.want[i])
+			}
+		}
+	}
+}
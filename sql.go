@@ -0,0 +1,134 @@
+package lengths
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// Value implements driver.Valuer so that lengths can be stored in integer
+// database columns as their nanometer count. Lengths longer than
+// math.MaxInt64 nanometers, about 9 gigameters, do not fit a signed 64-bit
+// column and return an error.
+func (l Length) Value() (driver.Value, error) {
+	if l > math.MaxInt64 {
+		return nil, errors.New("lengths: encoding SQL value: length overflows int64")
+	}
+	return int64(l), nil
+}
+
+// Scan implements sql.Scanner for lengths stored as done by Value. Integer
+// columns hold nanometer counts; text columns may also hold lengths as
+// parsed by ParseLength, such as "178cm". Use NullLength for nullable
+// columns.
+func (l *Length) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("lengths: scanning SQL value: negative nanometer count %d", v)
+		}
+		*l = Length(v)
+		return nil
+	case []byte:
+		return l.scanText(string(v))
+	case string:
+		return l.scanText(v)
+	case nil:
+		return errors.New("lengths: scanning SQL value: NULL into Length, use NullLength")
+	default:
+		return fmt.Errorf("lengths: scanning SQL value: unsupported type %T", src)
+	}
+}
+
+// scanText scans a nanometer count, as returned as text by some drivers for
+// integer columns, or a length as parsed by ParseLength.
+func (l *Length) scanText(s string) error {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*l = Length(n)
+		return nil
+	}
+	return l.UnmarshalText([]byte(s))
+}
+
+// NullLength is a length that may be NULL in a database, analogous to
+// sql.NullInt64. It implements sql.Scanner and driver.Valuer.
+type NullLength struct {
+	Length Length
+	Valid  bool // Valid is true if Length is not NULL.
+}
+
+// Scan implements sql.Scanner. A NULL value sets Valid to false and other
+// values are scanned as done by Length.Scan.
+func (n *NullLength) Scan(src interface{}) error {
+	if src == nil {
+		n.Length, n.Valid = 0, false
+		return nil
+	}
+	if err := n.Length.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer, returning nil if the length is not Valid.
+func (n NullLength) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Length.Value()
+}
+
+// Value implements driver.Valuer so that lengths can be stored in columns of
+// another unit than nanometers, such as integer millimeter columns of legacy
+// schemas. Whole numbers of the unit are stored as integers and other lengths
+// as floating point numbers, which may round them. Whole numbers that do not
+// fit a signed 64-bit column return an error.
+func (v InUnit) Value() (driver.Value, error) {
+	if v.Unit == 0 {
+		return nil, errors.New("lengths: encoding SQL value: zero unit")
+	}
+	if v.Length%v.Unit == 0 {
+		n := v.Length / v.Unit
+		if n > math.MaxInt64 {
+			return nil, errors.New("lengths: encoding SQL value: length overflows int64")
+		}
+		return int64(n), nil
+	}
+	return v.Length.in(v.Unit), nil
+}
+
+// Scan implements sql.Scanner for numbers of the unit, as stored by Value.
+// Text columns are scanned as decimal numbers of the unit.
+func (v *InUnit) Scan(src interface{}) error {
+	if v.Unit == 0 {
+		return errors.New("lengths: scanning SQL value: zero unit")
+	}
+	var s string
+	switch n := src.(type) {
+	case int64:
+		hi, lo := bits.Mul64(uint64(n), uint64(v.Unit))
+		if n < 0 || hi != 0 {
+			return fmt.Errorf("lengths: scanning SQL value: invalid number %d", n)
+		}
+		v.Length = Length(lo)
+		return nil
+	case float64:
+		s = strconv.FormatFloat(n, 'g', -1, 64)
+	case []byte:
+		s = string(n)
+	case string:
+		s = n
+	default:
+		return fmt.Errorf("lengths: scanning SQL value: unsupported type %T", src)
+	}
+	l, ok := numberIn(s, v.Unit)
+	if !ok {
+		return fmt.Errorf("lengths: scanning SQL value: invalid number %q", s)
+	}
+	v.Length = l
+	return nil
+}
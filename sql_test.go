@@ -0,0 +1,112 @@
+package lengths
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*Length)(nil)
+	_ driver.Valuer = Length(0)
+	_ sql.Scanner   = (*NullLength)(nil)
+	_ driver.Valuer = NullLength{}
+	_ sql.Scanner   = (*InUnit)(nil)
+	_ driver.Valuer = InUnit{}
+)
+
+func TestLengthSQL(t *testing.T) {
+	for _, l := range []Length{0, 178 * Centimeter, math.MaxInt64} {
+		v, err := l.Value()
+		if err != nil || v != int64(l) {
+			t.Errorf("Value(%q): got %v, %v, want %d", l, v, err, int64(l))
+			continue
+		}
+		var got Length
+		if err := got.Scan(v); err != nil || got != l {
+			t.Errorf("Scan(%v): got %q, %v, want %q", v, got, err, l)
+		}
+	}
+	if v, err := MaxLength.Value(); err == nil {
+		t.Errorf("Value(%q): got %v, want error", MaxLength, v)
+	}
+
+	testCases := []struct {
+		src  interface{}
+		want Length
+	}{
+		{src: []byte("1780000000"), want: 178 * Centimeter},
+		{src: "178cm", want: 178 * Centimeter},
+		{src: "0", want: 0},
+	}
+	for _, tc := range testCases {
+		var got Length
+		if err := got.Scan(tc.src); err != nil || got != tc.want {
+			t.Errorf("Scan(%v): got %q, %v, want %q", tc.src, got, err, tc.want)
+		}
+	}
+	for _, src := range []interface{}{nil, int64(-1), 1.78, "tall", true} {
+		var got Length
+		if err := got.Scan(src); err == nil {
+			t.Errorf("Scan(%v): got %q, want error", src, got)
+		}
+	}
+}
+
+func TestNullLengthSQL(t *testing.T) {
+	n := NullLength{Length: 5 * Centimeter, Valid: true}
+	if err := n.Scan(nil); err != nil || n.Valid || n.Length != 0 {
+		t.Errorf("Scan(nil): got %+v, %v, want invalid", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("Value(%+v): got %v, %v, want nil", n, v, err)
+	}
+	if err := n.Scan(int64(Meter)); err != nil || !n.Valid || n.Length != Meter {
+		t.Errorf("Scan(%d): got %+v, %v, want 1m", int64(Meter), n, err)
+	}
+	if v, err := n.Value(); err != nil || v != int64(Meter) {
+		t.Errorf("Value(%+v): got %v, %v, want %d", n, v, err, int64(Meter))
+	}
+}
+
+func TestInUnitSQL(t *testing.T) {
+	testCases := []struct {
+		v    InUnit
+		want driver.Value
+	}{
+		{v: InUnit{Length: 1785 * Millimeter, Unit: Millimeter}, want: int64(1785)},
+		{v: InUnit{Length: 6 * Foot, Unit: Inch}, want: int64(72)},
+		{v: InUnit{Length: 17855 * Millimeter / 10, Unit: Millimeter}, want: 1785.5},
+	}
+	for _, tc := range testCases {
+		got, err := tc.v.Value()
+		if err != nil || got != tc.want {
+			t.Errorf("Value(%q, %q): got %v, %v, want %v", tc.v.Length, tc.v.Unit, got, err, tc.want)
+			continue
+		}
+		s := InUnit{Unit: tc.v.Unit}
+		if err := s.Scan(got); err != nil || s.Length != tc.v.Length {
+			t.Errorf("Scan(%v): got %q, %v, want %q", got, s.Length, err, tc.v.Length)
+		}
+	}
+
+	s := InUnit{Unit: Millimeter}
+	if err := s.Scan([]byte("178.5")); err != nil || s.Length != 1785*Millimeter/10 {
+		t.Errorf("Scan(178.5): got %q, %v, want 178.5mm", s.Length, err)
+	}
+	for _, src := range []interface{}{int64(-1), int64(math.MaxInt64), "178mm", nil} {
+		if err := s.Scan(src); err == nil {
+			t.Errorf("Scan(%v): got %q, want error", src, s.Length)
+		}
+	}
+	if v, err := (InUnit{Length: Meter}).Value(); err == nil {
+		t.Errorf("Value(zero unit): got %v, want error", v)
+	}
+	if v, err := (InUnit{Length: MaxLength, Unit: Nanometer}).Value(); err == nil {
+		t.Errorf("Value(%q, %q): got %v, want error", MaxLength, Nanometer, v)
+	}
+	if v, err := (InUnit{Length: math.MaxInt64, Unit: Nanometer}).Value(); err != nil || v != int64(math.MaxInt64) {
+		t.Errorf("Value(%q, %q): got %v, %v, want %d", Length(math.MaxInt64), Nanometer, v, err, int64(math.MaxInt64))
+	}
+}
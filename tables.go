@@ -0,0 +1,82 @@
+package lengths
+
+import "math"
+
+// NearestGauge returns the name and size of the gauge of table closest to the
+// length, such as the drill or wire gauge matching a measured diameter. Ties
+// are broken toward the smaller size, then toward the name sorting first. ok
+// is false if table is empty.
+func (l Length) NearestGauge(table map[string]Length) (name string, size Length, ok bool) {
+	var best Length
+	for n, s := range table {
+		d := s - l
+		if s < l {
+			d = l - s
+		}
+		if ok && (d > best || d == best && (s > size || s == size && n > name)) {
+			continue
+		}
+		name, size, best, ok = n, s, d, true
+	}
+	return name, size, ok
+}
+
+// Label returns the name of the first entry of table whose Max is at least the
+// length, such as the clothing size for a chest measurement. table is assumed
+// sorted by ascending Max. Its last entry doubles as the overflow label: it is
+// returned for lengths exceeding every Max. Label returns "" if table is
+// empty.
+func (l Length) Label(table []struct {
+	Name string
+	Max  Length
+}) string {
+	for _, e := range table {
+		if l <= e.Max {
+			return e.Name
+		}
+	}
+	if len(table) == 0 {
+		return ""
+	}
+	return table[len(table)-1].Name
+}
+
+// CommonObjects holds the lengths of everyday objects for use with
+// ClosestObject. Applications may modify it or pass their own objects.
+var CommonObjects = map[string]Length{
+	"credit card":    85600 * Micrometer,
+	"A4 sheet":       297 * Millimeter,
+	"baseball bat":   1067 * Millimeter,
+	"door":           2032 * Millimeter,
+	"car":            450 * Centimeter,
+	"bus":            12 * Meter,
+	"tennis court":   2377 * Centimeter,
+	"football pitch": 105 * Meter,
+	"Eiffel Tower":   330 * Meter,
+}
+
+// ClosestObject returns the name of the object of objects closest in length
+// to the length, such as CommonObjects, and how many of that object the
+// length is, for consumer-friendly comparisons: a height of 2.1m is about
+// 1.03 doors.
+// Closeness is measured by ratio, so that 2 doors are as close to a door as
+// half a door is. Ties are broken toward the name sorting first. It returns ""
+// and 0 if the length is zero or if objects holds no object longer than zero.
+func (l Length) ClosestObject(objects map[string]Length) (name string, ratio float64) {
+	if l == 0 {
+		return "", 0
+	}
+	best := math.Inf(1)
+	for n, o := range objects {
+		if o == 0 {
+			continue
+		}
+		r := Ratio(l, o)
+		d := math.Abs(math.Log(r))
+		if d > best || d == best && n > name {
+			continue
+		}
+		name, ratio, best = n, r, d
+	}
+	return name, ratio
+}
@@ -0,0 +1,191 @@
+package lengths
+
+import "testing"
+
+func TestNearestGauge(t *testing.T) {
+	// Diameters of a few AWG wire gauges.
+	awg := map[string]Length{
+		"10 AWG": 2588 * Micrometer,
+		"12 AWG": 2053 * Micrometer,
+		"14 AWG": 1628 * Micrometer,
+		"16 AWG": 1291 * Micrometer,
+	}
+	testCases := []struct {
+		l        Length
+		table    map[string]Length
+		wantName string
+		wantSize Length
+		wantOK   bool
+	}{
+		{
+			l:        2 * Millimeter,
+			table:    awg,
+			wantName: "12 AWG",
+			wantSize: 2053 * Micrometer,
+			wantOK:   true,
+		},
+		{
+			l:        1800 * Micrometer,
+			table:    awg,
+			wantName: "14 AWG",
+			wantSize: 1628 * Micrometer,
+			wantOK:   true,
+		},
+		{
+			l:        1900 * Micrometer,
+			table:    awg,
+			wantName: "12 AWG",
+			wantSize: 2053 * Micrometer,
+			wantOK:   true,
+		},
+		{
+			l:        0,
+			table:    awg,
+			wantName: "16 AWG",
+			wantSize: 1291 * Micrometer,
+			wantOK:   true,
+		},
+		{
+			l:        1 * Centimeter,
+			table:    awg,
+			wantName: "10 AWG",
+			wantSize: 2588 * Micrometer,
+			wantOK:   true,
+		},
+		{
+			// Halfway between 14 and 12 AWG.
+			l:        18405 * (Micrometer / 10),
+			table:    awg,
+			wantName: "14 AWG",
+			wantSize: 1628 * Micrometer,
+			wantOK:   true,
+		},
+		{
+			l: 2 * Millimeter,
+			table: map[string]Length{
+				"B": 2 * Millimeter,
+				"A": 2 * Millimeter,
+			},
+			wantName: "A",
+			wantSize: 2 * Millimeter,
+			wantOK:   true,
+		},
+		{
+			l:        2 * Millimeter,
+			table:    nil,
+			wantName: "",
+			wantSize: 0,
+			wantOK:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		gotName, gotSize, gotOK := tc.l.NearestGauge(tc.table)
+		if gotName != tc.wantName || gotSize != tc.wantSize || gotOK != tc.wantOK {
+			t.Errorf(
+				"NearestGauge(%q): got %q, %q, %t, want %q, %q, %t",
+				tc.l,
+				gotName,
+				gotSize,
+				gotOK,
+				tc.wantName,
+				tc.wantSize,
+				tc.wantOK,
+			)
+		}
+	}
+}
+
+func TestLabel(t *testing.T) {
+	sizes := []struct {
+		Name string
+		Max  Length
+	}{
+		{Name: "S", Max: 92 * Centimeter},
+		{Name: "M", Max: 100 * Centimeter},
+		{Name: "L", Max: 108 * Centimeter},
+		{Name: "XL", Max: 116 * Centimeter},
+	}
+	testCases := []struct {
+		l     Length
+		table []struct {
+			Name string
+			Max  Length
+		}
+		want string
+	}{
+		{l: 80 * Centimeter, table: sizes, want: "S"},
+		{l: 92 * Centimeter, table: sizes, want: "S"},
+		{l: 92*Centimeter + 1, table: sizes, want: "M"},
+		{l: 105 * Centimeter, table: sizes, want: "L"},
+		{l: 116 * Centimeter, table: sizes, want: "XL"},
+		{l: 130 * Centimeter, table: sizes, want: "XL"},
+		{l: 100 * Centimeter, table: nil, want: ""},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.l.Label(tc.table); got != tc.want {
+			t.Errorf("Label(%q): got %q, want %q", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestClosestObject(t *testing.T) {
+	testCases := []struct {
+		l         Length
+		objects   map[string]Length
+		wantName  string
+		wantRatio float64
+	}{
+		{
+			l:         85600 * Micrometer,
+			objects:   CommonObjects,
+			wantName:  "credit card",
+			wantRatio: 1,
+		},
+		{
+			l:         210 * Centimeter,
+			objects:   CommonObjects,
+			wantName:  "door",
+			wantRatio: 2100.0 / 2032,
+		},
+		{
+			l:         24 * Meter,
+			objects:   CommonObjects,
+			wantName:  "tennis court",
+			wantRatio: 2400.0 / 2377,
+		},
+		{
+			l:         1 * Kilometer,
+			objects:   CommonObjects,
+			wantName:  "Eiffel Tower",
+			wantRatio: 1000.0 / 330,
+		},
+		{
+			// As close to a 1m object as to a 4m one.
+			l:         2 * Meter,
+			objects:   map[string]Length{"b": 1 * Meter, "a": 4 * Meter},
+			wantName:  "a",
+			wantRatio: 0.5,
+		},
+		{
+			l:         0,
+			objects:   CommonObjects,
+			wantName:  "",
+			wantRatio: 0,
+		},
+		{
+			l:         1 * Meter,
+			objects:   map[string]Length{"dot": 0},
+			wantName:  "",
+			wantRatio: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		gotName, gotRatio := tc.l.ClosestObject(tc.objects)
+		if gotName != tc.wantName || !floatEqual(gotRatio, tc.wantRatio) {
+			t.Errorf("ClosestObject(%q): got %q, %g, want %q, %g", tc.l, gotName, gotRatio, tc.wantName, tc.wantRatio)
+		}
+	}
+}
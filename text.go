@@ -0,0 +1,53 @@
+package lengths
+
+import "strings"
+
+// exactString returns the length formatted like String but with all the
+// digits needed to represent it exactly, which String may round for long
+// lengths: MaxLength is formatted as 18446744.073709551615km.
+func (l Length) exactString() string {
+	if l == 0 {
+		return "0"
+	}
+	unit := l.autoUnit()
+	return l.formatExact(unit) + unitSymbols[unit]
+}
+
+// formatExact returns the length as a number of unit, without symbol, with
+// all the decimals needed to represent it exactly if unit is a power of ten
+// nanometers, and as done by FormatUnit otherwise.
+func (l Length) formatExact(unit Length) string {
+	decimals := 0
+	for u := unit; u > 1; u /= 10 {
+		if u%10 != 0 {
+			return formatFloat(l.in(unit))
+		}
+		decimals++
+	}
+	s := l.formatNumber(unit, decimals)
+	if decimals > 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler. The length is formatted like
+// String, such as "1.78m", but exactly, so that it round-trips through
+// UnmarshalText. This makes text-based encodings such as JSON and XML encode
+// lengths as strings: JSON encodes 178cm as "1.78m" rather than as the
+// integer nanometer count 1780000000 it used before, which UnmarshalJSON
+// still decodes.
+func (l Length) MarshalText() ([]byte, error) {
+	return []byte(l.exactString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing the text as
+// done by ParseLength.
+func (l *Length) UnmarshalText(text []byte) error {
+	v, err := ParseLength(string(text))
+	if err != nil {
+		return err
+	}
+	*l = v
+	return nil
+}
@@ -0,0 +1,84 @@
+package lengths
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	testCases := []struct {
+		l    Length
+		want string
+	}{
+		{l: 0, want: "0"},
+		{l: 123 * Nanometer, want: "123nm"},
+		{l: 12345 * Nanometer, want: "12.345μm"},
+		{l: 42 * Centimeter, want: "42cm"},
+		{l: 178 * Centimeter, want: "1.78m"},
+		{l: 1234567891, want: "1.234567891m"},
+		{l: 42195 * Meter, want: "42.195km"},
+		{l: MaxLength, want: "18446744.073709551615km"},
+	}
+
+	for _, tc := range testCases {
+		b, err := tc.l.MarshalText()
+		if err != nil || string(b) != tc.want {
+			t.Errorf("MarshalText(%q): got %q, %v, want %q", tc.l, b, err, tc.want)
+			continue
+		}
+		var got Length
+		if err := got.UnmarshalText(b); err != nil || got != tc.l {
+			t.Errorf("UnmarshalText(%q): got %q, %v, want %q", b, got, err, tc.l)
+		}
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	l := 178 * Centimeter
+	if err := l.UnmarshalText([]byte("tall")); err == nil {
+		t.Errorf("UnmarshalText(%q): got %q, want error", "tall", l)
+	}
+	if l != 178*Centimeter {
+		t.Errorf("UnmarshalText(%q): modified length to %q", "tall", l)
+	}
+}
+
+func TestTextEncodings(t *testing.T) {
+	type person struct {
+		Height Length `json:"height" xml:"height"`
+	}
+
+	b, err := json.Marshal(person{Height: 178 * Centimeter})
+	if want := `{"height":"1.78m"}`; err != nil || string(b) != want {
+		t.Errorf("json.Marshal(): got %s, %v, want %s", b, err, want)
+	}
+	var p person
+	if err := json.Unmarshal([]byte(`{"height":"5'10\""}`), &p); err != nil || p.Height != 5*Foot+10*Inch {
+		t.Errorf("json.Unmarshal(): got %q, %v, want %q", p.Height, err, 5*Foot+10*Inch)
+	}
+
+	for _, s := range []string{`{"height":1780000000}`, `{"height":"1780000000nm"}`} {
+		var p person
+		if err := json.Unmarshal([]byte(s), &p); err != nil || p.Height != 178*Centimeter {
+			t.Errorf("json.Unmarshal(%s): got %q, %v, want %q", s, p.Height, err, 178*Centimeter)
+		}
+	}
+	p = person{Height: Meter}
+	if err := json.Unmarshal([]byte(`{"height":null}`), &p); err != nil || p.Height != Meter {
+		t.Errorf("json.Unmarshal(null): got %q, %v, want %q", p.Height, err, Meter)
+	}
+	for _, s := range []string{`{"height":-1}`, `{"height":1.78}`, `{"height":1e9}`, `{"height":18446744073709551616}`, `{"height":true}`} {
+		if err := json.Unmarshal([]byte(s), &p); err == nil {
+			t.Errorf("json.Unmarshal(%s): got %q, want error", s, p.Height)
+		}
+	}
+
+	b, err = xml.Marshal(person{Height: 178 * Centimeter})
+	if want := `<person><height>1.78m</height></person>`; err != nil || string(b) != want {
+		t.Errorf("xml.Marshal(): got %s, %v, want %s", b, err, want)
+	}
+	if err := xml.Unmarshal([]byte(`<person><height>42cm</height></person>`), &p); err != nil || p.Height != 42*Centimeter {
+		t.Errorf("xml.Unmarshal(): got %q, %v, want %q", p.Height, err, 42*Centimeter)
+	}
+}
@@ -0,0 +1,72 @@
+package lengths
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// unitAttr returns the name of the XML attribute holding the unit symbol.
+func (v InUnit) unitAttr() string {
+	if v.UnitAttr == "" {
+		return "unit"
+	}
+	return v.UnitAttr
+}
+
+// MarshalXML implements xml.Marshaler. The length is encoded as a number of
+// Unit with the unit symbol in the UnitAttr attribute, as exchanged with
+// garment PLM systems:
+//
+//	<height unit="cm">178</height>
+//
+// A zero Unit uses the unit String would use. The Format is ignored.
+func (v InUnit) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	unit := v.Unit
+	if unit == 0 {
+		unit = v.Length.autoUnit()
+	}
+	symbol, ok := unitSymbols[unit]
+	if !ok {
+		return errors.New("lengths: encoding XML: unit without symbol")
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: v.unitAttr()}, Value: symbol})
+	return e.EncodeElement(v.Length.formatExact(unit), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for elements encoded by MarshalXML.
+// The number is read in the unit of the UnitAttr attribute or, if the element
+// has none, in Unit. Elements with neither hold a length parsed as done by
+// ParseLength, such as <height>1.78m</height>.
+func (v *InUnit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	s = strings.TrimSpace(s)
+
+	unit := v.Unit
+	name := v.unitAttr()
+	for _, a := range start.Attr {
+		if a.Name.Local != name {
+			continue
+		}
+		u, ok := unitsBySymbol[a.Value]
+		if !ok {
+			return errors.New("lengths: decoding XML: unknown unit " + strconv.Quote(a.Value))
+		}
+		unit = u
+	}
+	if unit == 0 {
+		return v.Length.UnmarshalText([]byte(s))
+	}
+
+	l, ok := numberIn(s, unit)
+	if !ok {
+		return fmt.Errorf("lengths: decoding XML: invalid number %q", s)
+	}
+	v.Length = l
+	return nil
+}
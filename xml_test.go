@@ -0,0 +1,71 @@
+package lengths
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+// measurement holds an InUnit in an XML element.
+type measurement struct {
+	Height InUnit `xml:"height"`
+}
+
+func TestInUnitXML(t *testing.T) {
+	testCases := []struct {
+		v    InUnit
+		want string
+	}{
+		{v: InUnit{Length: 178 * Centimeter, Unit: Centimeter}, want: `<height unit="cm">178</height>`},
+		{v: InUnit{Length: 1785 * Millimeter, Unit: Centimeter}, want: `<height unit="cm">178.5</height>`},
+		{v: InUnit{Length: 178 * Centimeter}, want: `<height unit="m">1.78</height>`},
+		{v: InUnit{Length: 70 * Inch, Unit: Inch, UnitAttr: "uom"}, want: `<height uom="in">70</height>`},
+		{v: InUnit{Length: 0, Unit: Millimeter}, want: `<height unit="mm">0</height>`},
+	}
+
+	for _, tc := range testCases {
+		b, err := xml.Marshal(measurement{Height: tc.v})
+		if want := "<measurement>" + tc.want + "</measurement>"; err != nil || string(b) != want {
+			t.Errorf("MarshalXML(%q, %q): got %s, %v, want %s", tc.v.Length, tc.v.Unit, b, err, want)
+			continue
+		}
+		got := measurement{Height: InUnit{UnitAttr: tc.v.UnitAttr}}
+		if err := xml.Unmarshal(b, &got); err != nil || got.Height.Length != tc.v.Length {
+			t.Errorf("UnmarshalXML(%s): got %q, %v, want %q", b, got.Height.Length, err, tc.v.Length)
+		}
+	}
+
+	if _, err := xml.Marshal(measurement{Height: InUnit{Length: Meter, Unit: 3 * Meter}}); err == nil {
+		t.Errorf("MarshalXML(unit without symbol): got no error")
+	}
+}
+
+func TestInUnitUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		unit Length
+		in   string
+		want Length
+	}{
+		{unit: Millimeter, in: `<measurement><height>1780</height></measurement>`, want: 178 * Centimeter},
+		{unit: Millimeter, in: `<measurement><height unit="m"> 1.78 </height></measurement>`, want: 178 * Centimeter},
+		{unit: 0, in: `<measurement><height>5'10"</height></measurement>`, want: 70 * Inch},
+	}
+
+	for _, tc := range testCases {
+		got := measurement{Height: InUnit{Unit: tc.unit}}
+		if err := xml.Unmarshal([]byte(tc.in), &got); err != nil || got.Height.Length != tc.want {
+			t.Errorf("UnmarshalXML(%s): got %q, %v, want %q", tc.in, got.Height.Length, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{
+		`<measurement><height unit="cubit">1</height></measurement>`,
+		`<measurement><height unit="cm">-1</height></measurement>`,
+		`<measurement><height unit="cm">178cm</height></measurement>`,
+		`<measurement><height>tall</height></measurement>`,
+	} {
+		var got measurement
+		if err := xml.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("UnmarshalXML(%s): got %q, want error", in, got.Height.Length)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package lengths
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2
+// and gopkg.in/yaml.v3 without importing either: the length is encoded as a
+// string formatted as done by MarshalText, such as "2.1m".
+func (l Length) MarshalYAML() (interface{}, error) {
+	return l.exactString(), nil
+}
+
+// UnmarshalYAML implements the obsolete yaml.Unmarshaler interface, still
+// supported by gopkg.in/yaml.v3, so that configuration such as
+// "max_height: 2.1m" decodes into Length fields. The scalar is parsed as done
+// by ParseLength.
+func (l *Length) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(s))
+}
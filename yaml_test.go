@@ -0,0 +1,34 @@
+package lengths
+
+import "testing"
+
+func TestYAML(t *testing.T) {
+	for _, l := range []Length{0, 210 * Centimeter, MaxLength} {
+		v, err := l.MarshalYAML()
+		s, ok := v.(string)
+		if err != nil || !ok || s != l.exactString() {
+			t.Errorf("MarshalYAML(%q): got %v, %v, want %q", l, v, err, l.exactString())
+			continue
+		}
+		var got Length
+		if err := got.UnmarshalYAML(yamlScalar(s)); err != nil || got != l {
+			t.Errorf("UnmarshalYAML(%q): got %q, %v, want %q", s, got, err, l)
+		}
+	}
+
+	for _, s := range []string{"", "2.1", "tall"} {
+		var got Length
+		if err := got.UnmarshalYAML(yamlScalar(s)); err == nil {
+			t.Errorf("UnmarshalYAML(%q): got %q, want error", s, got)
+		}
+	}
+}
+
+// yamlScalar returns an unmarshal function decoding the scalar s as done by
+// YAML libraries.
+func yamlScalar(s string) func(interface{}) error {
+	return func(v interface{}) error {
+		*v.(*string) = s
+		return nil
+	}
+}